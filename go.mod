@@ -1,3 +1,5 @@
 module github.com/maileroo/maileroo-go-sdk
 
 go 1.21
+
+require golang.org/x/net v0.27.0
@@ -0,0 +1,218 @@
+package maileroo
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+const ABVariantTagKey = "ab_variant"
+
+type ABVariant struct {
+	Label      string
+	Subject    string
+	HTML       *string
+	Plain      *string
+	TemplateID *int
+	Weight     int
+}
+
+type ABTestData struct {
+	From        EmailAddress
+	Recipients  []EmailAddress
+	Variants    []ABVariant
+	Seed        string
+	Tracking    *bool
+	Tags        AssocMap
+	Headers     AssocMap
+	Attachments []Attachment
+}
+
+type ABTestResult struct {
+	Assignments  map[string]string
+	ReferenceIDs map[string][]string
+}
+
+func (c *Client) SendABTest(ctx context.Context, data ABTestData) (*ABTestResult, error) {
+
+	if len(data.Variants) < 2 {
+		return nil, newFieldError("variants", FieldErrorRequired, "an A/B test requires at least two variants")
+	}
+
+	if len(data.Recipients) == 0 {
+		return nil, newFieldError("recipients", FieldErrorRequired, "recipients must be a non-empty array")
+	}
+
+	labels := map[string]bool{}
+	totalWeight := 0
+
+	for i, v := range data.Variants {
+
+		if strings.TrimSpace(v.Label) == "" {
+			return nil, newFieldError(fmt.Sprintf("variants[%d].label", i), FieldErrorRequired, "variants[%d].label is required", i)
+		}
+
+		if labels[v.Label] {
+			return nil, newFieldError(fmt.Sprintf("variants[%d].label", i), FieldErrorDuplicate, "variants[%d].label %q is not unique", i, v.Label)
+		}
+
+		if v.Weight < 0 {
+			return nil, newFieldError(fmt.Sprintf("variants[%d].weight", i), FieldErrorInvalid, "variants[%d].weight must not be negative", i)
+		}
+
+		// Every variant that can receive traffic is checked up front, so a bad
+		// last variant cannot fail the test after earlier ones were sent.
+		if v.Weight > 0 {
+
+			if err := v.validate(); err != nil {
+				return nil, withFieldPrefix(fmt.Sprintf("variants[%d]", i), err)
+			}
+
+		}
+
+		labels[v.Label] = true
+		totalWeight += v.Weight
+
+	}
+
+	if totalWeight == 0 {
+		return nil, newFieldError("variants", FieldErrorRequired, "at least one variant must have a positive weight")
+	}
+
+	recipients, err := c.expandGroups(ctx, data.Recipients)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ABTestResult{
+		Assignments:  map[string]string{},
+		ReferenceIDs: map[string][]string{},
+	}
+
+	groups := make([][]EmailAddress, len(data.Variants))
+
+	for _, r := range recipients {
+
+		key := strings.ToLower(strings.TrimSpace(r.Address))
+
+		if _, ok := result.Assignments[key]; ok {
+			continue
+		}
+
+		idx := assignVariant(data.Seed, key, data.Variants, totalWeight)
+
+		result.Assignments[key] = data.Variants[idx].Label
+		groups[idx] = append(groups[idx], r)
+
+	}
+
+	totalBatches := 0
+
+	for _, g := range groups {
+		totalBatches += (len(g) + maxBulkMessages - 1) / maxBulkMessages
+	}
+
+	var sent []string
+	batches := 0
+
+	for i, v := range data.Variants {
+
+		recipients := groups[i]
+
+		for start := 0; start < len(recipients); start += maxBulkMessages {
+
+			if err := ctx.Err(); err != nil {
+				return result, newPartialResult(sent, batches, totalBatches, err)
+			}
+
+			end := min(start+maxBulkMessages, len(recipients))
+
+			tags := AssocMap{}
+
+			for k, val := range data.Tags {
+				tags[k] = val
+			}
+
+			tags[ABVariantTagKey] = v.Label
+
+			messages := make([]BulkMessage, 0, end-start)
+
+			for _, r := range recipients[start:end] {
+				messages = append(messages, BulkMessage{
+					From: data.From,
+					To:   []EmailAddress{r},
+				})
+			}
+
+			ids, err := c.SendBulkEmails(ctx, BulkEmailData{
+				Subject:     v.Subject,
+				HTML:        v.HTML,
+				Plain:       v.Plain,
+				TemplateID:  v.TemplateID,
+				Tracking:    data.Tracking,
+				Tags:        tags,
+				Headers:     data.Headers,
+				Attachments: data.Attachments,
+				Messages:    messages,
+			})
+
+			if err != nil {
+				return result, newPartialResult(sent, batches, totalBatches, fmt.Errorf("variant %q: %w", v.Label, err))
+			}
+
+			result.ReferenceIDs[v.Label] = append(result.ReferenceIDs[v.Label], ids...)
+			sent = append(sent, ids...)
+			batches++
+
+		}
+
+	}
+
+	return result, nil
+
+}
+
+func (v ABVariant) validate() error {
+
+	if err := requireSubject(v.Subject); err != nil {
+		return err
+	}
+
+	hasBody := v.HTML != nil || v.Plain != nil
+
+	if !hasBody && v.TemplateID == nil {
+		return newFieldError("template_id", FieldErrorRequired, "you must provide either html, plain, or template_id")
+	}
+
+	if hasBody && v.TemplateID != nil {
+		return newFieldError("template_id", FieldErrorConflict, "template_id cannot be combined with html or plain")
+	}
+
+	return nil
+
+}
+
+func assignVariant(seed, address string, variants []ABVariant, totalWeight int) int {
+
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	h.Write([]byte{0})
+	h.Write([]byte(address))
+
+	bucket := int(h.Sum64() % uint64(totalWeight))
+
+	for i, v := range variants {
+
+		bucket -= v.Weight
+
+		if bucket < 0 {
+			return i
+		}
+
+	}
+
+	return len(variants) - 1
+
+}
@@ -0,0 +1,154 @@
+package maileroo
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+type APIFieldErrors map[string][]string
+
+type APIError struct {
+	Message string
+	Errors  map[string][]string
+}
+
+func (e *APIError) Error() string {
+
+	msg := "the API returned an error: " + e.Message
+
+	if len(e.Errors) == 0 {
+		return msg
+	}
+
+	fields := make([]string, 0, len(e.Errors))
+
+	for f := range e.Errors {
+		fields = append(fields, f)
+	}
+
+	sort.Strings(fields)
+
+	parts := make([]string, 0, len(fields))
+
+	for _, f := range fields {
+
+		if f == "" {
+			parts = append(parts, strings.Join(e.Errors[f], ", "))
+			continue
+		}
+
+		parts = append(parts, f+": "+strings.Join(e.Errors[f], ", "))
+
+	}
+
+	return msg + " (" + strings.Join(parts, "; ") + ")"
+
+}
+
+func (e *APIError) FieldErrors(field string) []string {
+	return e.Errors[field]
+}
+
+func newAPIError(message string, errs ...APIFieldErrors) *APIError {
+
+	if message == "" {
+		message = "Unknown"
+	}
+
+	return &APIError{Message: message, Errors: mergeFieldErrors(errs...)}
+
+}
+
+func mergeFieldErrors(errs ...APIFieldErrors) APIFieldErrors {
+
+	var out APIFieldErrors
+
+	for _, fe := range errs {
+
+		for f, msgs := range fe {
+			out.add(f, msgs...)
+		}
+
+	}
+
+	return out
+
+}
+
+func (fe *APIFieldErrors) UnmarshalJSON(b []byte) error {
+
+	*fe = nil
+
+	var byField map[string]json.RawMessage
+
+	if json.Unmarshal(b, &byField) == nil {
+
+		for f, raw := range byField {
+			fe.addRaw(f, raw)
+		}
+
+		return nil
+
+	}
+
+	var list []json.RawMessage
+
+	if json.Unmarshal(b, &list) == nil {
+
+		for _, raw := range list {
+
+			var item struct {
+				Field   string `json:"field"`
+				Message string `json:"message"`
+			}
+
+			if json.Unmarshal(raw, &item) == nil && item.Message != "" {
+				fe.add(item.Field, item.Message)
+				continue
+			}
+
+			fe.addRaw("", raw)
+
+		}
+
+	}
+
+	return nil
+
+}
+
+func (fe *APIFieldErrors) addRaw(field string, raw json.RawMessage) {
+
+	var one string
+	var many []string
+
+	switch {
+
+	case json.Unmarshal(raw, &one) == nil:
+		fe.add(field, one)
+
+	case json.Unmarshal(raw, &many) == nil:
+		fe.add(field, many...)
+
+	}
+
+}
+
+func (fe *APIFieldErrors) add(field string, msgs ...string) {
+
+	for _, m := range msgs {
+
+		if m = strings.TrimSpace(m); m == "" {
+			continue
+		}
+
+		if *fe == nil {
+			*fe = APIFieldErrors{}
+		}
+
+		(*fe)[field] = append((*fe)[field], m)
+
+	}
+
+}
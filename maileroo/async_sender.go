@@ -0,0 +1,445 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const (
+	DefaultAsyncQueueSize = 1000
+	DefaultAsyncWorkers   = 4
+)
+
+var (
+	ErrQueueFull    = errors.New("async send queue is full")
+	ErrSenderClosed = errors.New("async sender is closed")
+)
+
+type AsyncSenderOptions struct {
+	QueueSize int
+	Workers   int
+	BatchSize int
+	OnError   func(data any, err error)
+	OnSent    func(data any, referenceIDs []string)
+}
+
+type AsyncSender struct {
+	client *Client
+	opts   AsyncSenderOptions
+	queue  chan asyncJob
+	cancel context.CancelFunc
+	ctx    context.Context
+
+	mu      sync.Mutex
+	closed  bool
+	closing chan struct{}
+	pending sync.WaitGroup
+	wg      sync.WaitGroup
+}
+
+type asyncJob struct {
+	ctx  context.Context
+	data any
+}
+
+func (c *Client) NewAsyncSender(opts AsyncSenderOptions) (*AsyncSender, error) {
+
+	if opts.QueueSize < 0 || opts.Workers < 0 || opts.BatchSize < 0 {
+		return nil, errors.New("async queue size, workers and batch size must not be negative")
+	}
+
+	if opts.QueueSize == 0 {
+		opts.QueueSize = DefaultAsyncQueueSize
+	}
+
+	if opts.Workers == 0 {
+		opts.Workers = DefaultAsyncWorkers
+	}
+
+	opts.BatchSize = min(max(opts.BatchSize, 1), maxBulkMessages)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &AsyncSender{
+		client:  c,
+		opts:    opts,
+		queue:   make(chan asyncJob, opts.QueueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+		closing: make(chan struct{}),
+	}
+
+	if err := c.lifecycle.register(s); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	s.wg.Add(opts.Workers)
+
+	for i := 0; i < opts.Workers; i++ {
+		go s.work()
+	}
+
+	return s, nil
+
+}
+
+func (s *AsyncSender) Enqueue(ctx context.Context, data any) error {
+	return s.enqueue(ctx, data, false)
+}
+
+func (s *AsyncSender) EnqueueWait(ctx context.Context, data any) error {
+	return s.enqueue(ctx, data, true)
+}
+
+func (s *AsyncSender) enqueue(ctx context.Context, data any, wait bool) error {
+
+	job, err := newAsyncJob(ctx, data)
+
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+
+	if s.closed {
+		s.mu.Unlock()
+		return ErrSenderClosed
+	}
+
+	s.pending.Add(1)
+	s.mu.Unlock()
+
+	defer s.pending.Done()
+
+	if !wait {
+
+		select {
+
+		case s.queue <- job:
+			return nil
+
+		default:
+			return ErrQueueFull
+
+		}
+
+	}
+
+	select {
+
+	case s.queue <- job:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case <-s.closing:
+		return ErrSenderClosed
+
+	}
+
+}
+
+func (s *AsyncSender) Len() int {
+	return len(s.queue)
+}
+
+func (s *AsyncSender) Close(ctx context.Context) error {
+
+	s.mu.Lock()
+
+	if !s.closed {
+
+		s.closed = true
+		close(s.closing)
+
+		s.pending.Wait()
+		close(s.queue)
+
+	}
+
+	s.mu.Unlock()
+
+	defer s.client.lifecycle.unregister(s)
+
+	done := make(chan struct{})
+
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+
+	case <-done:
+		s.cancel()
+		return nil
+
+	case <-ctx.Done():
+		s.cancel()
+		<-done
+		return ctx.Err()
+
+	}
+
+}
+
+func newAsyncJob(ctx context.Context, data any) (asyncJob, error) {
+
+	switch data.(type) {
+
+	case BasicEmailData, TemplatedEmailData, BulkEmailData:
+		return asyncJob{ctx: context.WithoutCancel(ctx), data: data}, nil
+
+	default:
+		return asyncJob{}, fmt.Errorf("cannot enqueue %T: expected BasicEmailData, TemplatedEmailData or BulkEmailData", data)
+
+	}
+
+}
+
+func (s *AsyncSender) work() {
+
+	defer s.wg.Done()
+
+	for job := range s.queue {
+
+		batch := []asyncJob{job}
+
+		if s.batchable(job) {
+			batch = s.fill(batch)
+		}
+
+		s.sendBatch(batch)
+
+	}
+
+}
+
+func (s *AsyncSender) fill(batch []asyncJob) []asyncJob {
+
+	for len(batch) < s.opts.BatchSize {
+
+		select {
+
+		case job, ok := <-s.queue:
+
+			if !ok {
+				return batch
+			}
+
+			batch = append(batch, job)
+
+		default:
+			return batch
+
+		}
+
+	}
+
+	return batch
+
+}
+
+func (s *AsyncSender) batchable(job asyncJob) bool {
+
+	if s.opts.BatchSize < 2 || s.client.complaintPolicy != nil {
+		return false
+	}
+
+	t, ok := job.data.(TemplatedEmailData)
+
+	return ok && len(t.Attachments) == 0
+
+}
+
+func (s *AsyncSender) sendBatch(batch []asyncJob) {
+
+	var groups [][]asyncJob
+
+	for _, job := range batch {
+
+		placed := false
+
+		if s.batchable(job) {
+
+			for i, g := range groups {
+
+				if s.batchable(g[0]) && sameBulkRequest(g[0].data.(TemplatedEmailData), job.data.(TemplatedEmailData)) {
+					groups[i] = append(g, job)
+					placed = true
+					break
+				}
+
+			}
+
+		}
+
+		if !placed {
+			groups = append(groups, []asyncJob{job})
+		}
+
+	}
+
+	for _, g := range groups {
+
+		if len(g) == 1 {
+			s.sendOne(g[0])
+			continue
+		}
+
+		s.sendBulk(g)
+
+	}
+
+}
+
+// Jobs are merged only when SendBulkEmails will post them in a single request,
+// so a failure applies to every job in the batch and none was delivered.
+func sameBulkRequest(a, b TemplatedEmailData) bool {
+
+	if a.TemplateID != b.TemplateID {
+		return false
+	}
+
+	as, err := interpolatePlaceholders("subject", a.Subject, a.TemplateData, false)
+
+	if err != nil {
+		return false
+	}
+
+	bs, err := interpolatePlaceholders("subject", b.Subject, b.TemplateData, false)
+
+	return err == nil && as == bs && sameBulkSettings(a, b)
+
+}
+
+func sameBulkSettings(a, b TemplatedEmailData) bool {
+
+	return a.Priority == b.Priority &&
+		reflect.DeepEqual(a.Tracking, b.Tracking) &&
+		reflect.DeepEqual(a.TrackingSettings, b.TrackingSettings) &&
+		reflect.DeepEqual(a.Tags, b.Tags) &&
+		reflect.DeepEqual(a.Headers, b.Headers)
+
+}
+
+func (s *AsyncSender) sendOne(job asyncJob) {
+
+	ctx, cancel := s.jobContext(job)
+	defer cancel()
+
+	var ids []string
+	var err error
+
+	switch d := job.data.(type) {
+
+	case BasicEmailData:
+		var id string
+		id, err = s.client.SendBasicEmail(ctx, d)
+		ids = []string{id}
+
+	case TemplatedEmailData:
+		var id string
+		id, err = s.client.SendTemplatedEmail(ctx, d)
+		ids = []string{id}
+
+	case BulkEmailData:
+		ids, err = s.client.SendBulkEmailsChunked(ctx, d)
+
+	}
+
+	s.report(job.data, ids, err)
+
+}
+
+func (s *AsyncSender) sendBulk(jobs []asyncJob) {
+
+	ctx, cancel := s.jobContext(jobs[0])
+	defer cancel()
+
+	first := jobs[0].data.(TemplatedEmailData)
+
+	data := BulkEmailData{
+		Priority:         first.Priority,
+		Tracking:         first.Tracking,
+		Tags:             first.Tags,
+		Headers:          first.Headers,
+		TrackingSettings: first.TrackingSettings,
+		Messages:         make([]BulkMessage, 0, len(jobs)),
+	}
+
+	for _, job := range jobs {
+
+		t := job.data.(TemplatedEmailData)
+		tid := t.TemplateID
+
+		data.Messages = append(data.Messages, BulkMessage{
+			From:         t.From,
+			To:           t.To,
+			Cc:           t.Cc,
+			Bcc:          t.Bcc,
+			ReplyTo:      t.ReplyTo,
+			Subject:      t.Subject,
+			ReferenceID:  t.ReferenceID,
+			TemplateID:   &tid,
+			TemplateData: t.TemplateData,
+			ScheduledAt:  t.ScheduledAt,
+		})
+
+	}
+
+	ids, err := s.client.SendBulkEmails(ctx, data)
+
+	for i, job := range jobs {
+
+		if err != nil {
+			s.report(job.data, nil, err)
+			continue
+		}
+
+		var jobIDs []string
+
+		if i < len(ids) && ids[i] != "" {
+			jobIDs = ids[i : i+1]
+		}
+
+		s.report(job.data, jobIDs, nil)
+
+	}
+
+}
+
+func (s *AsyncSender) jobContext(job asyncJob) (context.Context, context.CancelFunc) {
+
+	ctx, cancel := context.WithCancel(context.WithValue(job.ctx, drainKey{}, true))
+	stop := context.AfterFunc(s.ctx, cancel)
+
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+
+}
+
+func (s *AsyncSender) report(data any, ids []string, err error) {
+
+	if err != nil {
+
+		if s.opts.OnError != nil {
+			s.opts.OnError(data, err)
+		}
+
+		return
+
+	}
+
+	if s.opts.OnSent != nil {
+		s.opts.OnSent(data, ids)
+	}
+
+}
@@ -1,6 +1,7 @@
 package maileroo
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"io"
@@ -8,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -72,20 +74,22 @@ type Attachment struct {
 	ContentType string `json:"content_type"`
 	Content     string `json:"content"`
 	Inline      bool   `json:"inline"`
+
+	path string
 }
 
 func NewAttachment(fileName, contentB64 string, contentType string, inline bool) (*Attachment, error) {
 
 	if strings.TrimSpace(fileName) == "" {
-		return nil, errors.New("file_name is required")
+		return nil, newFieldError("file_name", FieldErrorRequired, "file_name is required")
 	}
 
 	if strings.TrimSpace(contentB64) == "" {
-		return nil, errors.New("content must be a non-empty base64 string")
+		return nil, newFieldError("content", FieldErrorRequired, "content must be a non-empty base64 string")
 	}
 
 	if _, err := base64.StdEncoding.DecodeString(contentB64); err != nil {
-		return nil, errors.New("invalid base64 content provided")
+		return nil, newFieldError("content", FieldErrorInvalid, "invalid base64 content provided")
 	}
 
 	ct := contentType
@@ -106,7 +110,7 @@ func NewAttachment(fileName, contentB64 string, contentType string, inline bool)
 func AttachmentFromContent(fileName string, content []byte, contentType string, inline bool) (*Attachment, error) {
 
 	if strings.TrimSpace(fileName) == "" {
-		return nil, errors.New("file_name is required")
+		return nil, newFieldError("file_name", FieldErrorRequired, "file_name is required")
 	}
 
 	ct := contentType
@@ -137,7 +141,7 @@ func AttachmentFromBase64Content(fileName, contentB64, contentType string, inlin
 	raw, err := base64.StdEncoding.DecodeString(contentB64)
 
 	if err != nil {
-		return nil, errors.New("invalid base64 content provided")
+		return nil, newFieldError("content", FieldErrorInvalid, "invalid base64 content provided")
 	}
 
 	ct := contentType
@@ -159,7 +163,7 @@ func AttachmentFromBase64Content(fileName, contentB64, contentType string, inlin
 func AttachmentFromStream(fileName string, r io.Reader, contentType string, inline bool) (*Attachment, error) {
 
 	if r == nil {
-		return nil, errors.New("stream must be a valid, non-nil reader")
+		return nil, newFieldError("stream", FieldErrorRequired, "stream must be a valid, non-nil reader")
 	}
 
 	data, err := io.ReadAll(r)
@@ -216,6 +220,22 @@ func AttachmentFromFile(path string, contentType string, inline bool) (*Attachme
 
 }
 
+var mediaTypeRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}/[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]{0,126}$`)
+
+func (a *Attachment) SetContentType(contentType string) error {
+
+	mt, params, err := mime.ParseMediaType(strings.TrimSpace(contentType))
+
+	if err != nil || !mediaTypeRe.MatchString(mt) {
+		return newFieldError("content_type", FieldErrorInvalid, "invalid content type %q: expected type/subtype", contentType)
+	}
+
+	a.ContentType = mime.FormatMediaType(mt, params)
+
+	return nil
+
+}
+
 func (a *Attachment) ToMap() map[string]any {
 
 	ct := a.ContentType
@@ -224,13 +244,15 @@ func (a *Attachment) ToMap() map[string]any {
 		ct = "application/octet-stream"
 	}
 
-	return map[string]any{
+	m := map[string]any{
 		"file_name":    a.FileName,
 		"content_type": ct,
 		"content":      a.Content,
 		"inline":       a.Inline,
 	}
 
+	return m
+
 }
 
 func detectMimeFromPath(path string) string {
@@ -279,12 +301,27 @@ func detectMimeFromBuffer(buf []byte) string {
 
 	mt := http.DetectContentType(buf)
 
-	if mt == "" {
-		return ""
+	if i := strings.IndexByte(mt, ';'); i > 0 {
+		mt = strings.TrimSpace(mt[:i])
 	}
 
-	if i := strings.IndexByte(mt, ';'); i > 0 {
-		return strings.TrimSpace(mt[:i])
+	switch mt {
+
+	case "application/zip":
+		return detectMimeFromZip(buf)
+
+	case "text/plain":
+
+		if bytes.HasPrefix(buf, rtfMagic) {
+			return "application/rtf"
+		}
+
+	case "application/octet-stream":
+
+		if magic := detectMimeFromMagic(buf); magic != "" {
+			return magic
+		}
+
 	}
 
 	return mt
@@ -294,15 +331,23 @@ func detectMimeFromBuffer(buf []byte) string {
 func (a *Attachment) validate() error {
 
 	if strings.TrimSpace(a.FileName) == "" {
-		return errors.New("attachment.file_name is required")
+		return newFieldError("file_name", FieldErrorRequired, "attachment.file_name is required")
+	}
+
+	if containsControl(a.FileName) {
+		return newInjectionError("file_name", "attachment.file_name")
+	}
+
+	if containsControl(a.ContentType) {
+		return newInjectionError("content_type", "attachment.content_type")
 	}
 
 	if strings.TrimSpace(a.Content) == "" {
-		return errors.New("attachment.content_base64 must be a non-empty base64 string")
+		return newFieldError("content", FieldErrorRequired, "attachment.content_base64 must be a non-empty base64 string")
 	}
 
 	if strings.TrimSpace(a.ContentType) == "" {
-		return errors.New("attachment.content_type is required")
+		return newFieldError("content_type", FieldErrorRequired, "attachment.content_type is required")
 	}
 
 	return nil
@@ -0,0 +1,288 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	LinkedAttachmentsTagKey      = "linked_attachments"
+	AttachmentLinksTemplateField = "attachment_links"
+)
+
+type AttachmentStorage interface {
+	Upload(ctx context.Context, att Attachment, content []byte) (string, error)
+}
+
+type AttachmentStorageFunc func(ctx context.Context, att Attachment, content []byte) (string, error)
+
+func (f AttachmentStorageFunc) Upload(ctx context.Context, att Attachment, content []byte) (string, error) {
+	return f(ctx, att, content)
+}
+
+type AttachmentBudget struct {
+	MaxTotalSize int64
+	Storage      AttachmentStorage
+}
+
+type AttachmentLink struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	URL         string
+}
+
+func WithAttachmentBudget(budget AttachmentBudget) ClientOption {
+	return func(c *Client) error {
+		if budget.MaxTotalSize <= 0 {
+			return errors.New("attachment budget must be a positive number of bytes")
+		}
+		b := budget
+		c.attachmentBudget = &b
+		return nil
+	}
+}
+
+type budgetedAttachment struct {
+	index   int
+	content []byte
+}
+
+func (c *Client) applyAttachmentBudget(ctx context.Context, atts []Attachment) ([]Attachment, []AttachmentLink, error) {
+
+	if c.attachmentBudget == nil || len(atts) == 0 {
+		return atts, nil, nil
+	}
+
+	var total int64
+	var movable []budgetedAttachment
+
+	for i, att := range atts {
+
+		content, err := base64.StdEncoding.DecodeString(att.Content)
+
+		if err != nil {
+			return nil, nil, newFieldError(fmt.Sprintf("attachments[%d].content", i), FieldErrorInvalid, "attachments[%d]: invalid base64 content provided", i)
+		}
+
+		total += int64(len(content))
+
+		if !att.Inline {
+			movable = append(movable, budgetedAttachment{index: i, content: content})
+		}
+
+	}
+
+	budget := c.attachmentBudget
+
+	if total <= budget.MaxTotalSize {
+		return atts, nil, nil
+	}
+
+	if budget.Storage == nil {
+		return nil, nil, newFieldError("attachments", FieldErrorTooLarge, "attachments total %d bytes, exceeding the budget of %d bytes", total, budget.MaxTotalSize)
+	}
+
+	sort.SliceStable(movable, func(i, j int) bool {
+		return len(movable[i].content) > len(movable[j].content)
+	})
+
+	linked := map[int]AttachmentLink{}
+
+	for _, m := range movable {
+
+		if total <= budget.MaxTotalSize {
+			break
+		}
+
+		att := atts[m.index]
+
+		if err := att.validate(); err != nil {
+			return nil, nil, withFieldPrefix(fmt.Sprintf("attachments[%d]", m.index), err)
+		}
+
+		url, err := uploadBudgetedAttachment(ctx, budget.Storage, att, m.content)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("attachments[%d]: failed to upload %q: %w", m.index, att.FileName, err)
+		}
+
+		if strings.TrimSpace(url) == "" {
+			return nil, nil, fmt.Errorf("attachments[%d]: storage returned an empty URL for %q", m.index, att.FileName)
+		}
+
+		linked[m.index] = AttachmentLink{
+			FileName:    att.FileName,
+			ContentType: att.ContentType,
+			Size:        int64(len(m.content)),
+			URL:         url,
+		}
+
+		total -= int64(len(m.content))
+
+	}
+
+	if total > budget.MaxTotalSize {
+		return nil, nil, newFieldError("attachments", FieldErrorTooLarge, "inline attachments total %d bytes, exceeding the budget of %d bytes", total, budget.MaxTotalSize)
+	}
+
+	kept := make([]Attachment, 0, len(atts)-len(linked))
+	links := make([]AttachmentLink, 0, len(linked))
+
+	for i, att := range atts {
+
+		if link, ok := linked[i]; ok {
+			links = append(links, link)
+			continue
+		}
+
+		kept = append(kept, att)
+
+	}
+
+	return kept, links, nil
+
+}
+
+func appendAttachmentLinks(htmlBody, plain *string, links []AttachmentLink) (*string, *string) {
+
+	if len(links) == 0 {
+		return htmlBody, plain
+	}
+
+	if htmlBody != nil {
+
+		var b strings.Builder
+
+		b.WriteString(`<p>The following attachments were too large to include and can be downloaded here:</p><ul>`)
+
+		for _, l := range links {
+			fmt.Fprintf(&b, `<li><a href="%s">%s</a> (%s)</li>`, html.EscapeString(l.URL), html.EscapeString(l.FileName), formatByteSize(l.Size))
+		}
+
+		b.WriteString(`</ul>`)
+
+		s := insertBeforeBodyEnd(*htmlBody, b.String())
+		htmlBody = &s
+
+	}
+
+	if plain != nil {
+
+		var b strings.Builder
+
+		b.WriteString(*plain)
+		b.WriteString("\n\nThe following attachments were too large to include and can be downloaded here:\n")
+
+		for _, l := range links {
+			fmt.Fprintf(&b, "\n- %s (%s): %s", l.FileName, formatByteSize(l.Size), l.URL)
+		}
+
+		s := b.String()
+		plain = &s
+
+	}
+
+	return htmlBody, plain
+
+}
+
+func insertBeforeBodyEnd(doc, fragment string) string {
+
+	if i := strings.LastIndex(strings.ToLower(doc), "</body>"); i >= 0 {
+		return doc[:i] + fragment + doc[i:]
+	}
+
+	return doc + fragment
+
+}
+
+func attachmentLinksTemplateValue(links []AttachmentLink) []any {
+
+	out := make([]any, 0, len(links))
+
+	for _, l := range links {
+		out = append(out, map[string]any{
+			"file_name":    l.FileName,
+			"content_type": l.ContentType,
+			"size":         l.Size,
+			"url":          l.URL,
+		})
+	}
+
+	return out
+
+}
+
+// Estimates size the link with a placeholder URL instead of uploading.
+func uploadBudgetedAttachment(ctx context.Context, storage AttachmentStorage, att Attachment, content []byte) (string, error) {
+
+	if isEstimating(ctx) {
+		return "https://attachments.invalid/" + url.PathEscape(att.FileName), nil
+	}
+
+	return storage.Upload(ctx, att, content)
+
+}
+
+func (c *Client) offloadAttachments(ctx context.Context, payload map[string]any) ([]AttachmentLink, error) {
+
+	atts, _ := payload["attachments"].([]Attachment)
+
+	kept, links, err := c.applyAttachmentBudget(ctx, atts)
+
+	if err != nil || len(links) == 0 {
+		return nil, err
+	}
+
+	if len(kept) > 0 {
+		payload["attachments"] = kept
+	} else {
+		delete(payload, "attachments")
+	}
+
+	tags, _ := payload["tags"].(AssocMap)
+	payload["tags"] = mergeAssocMaps(tags, AssocMap{LinkedAttachmentsTagKey: len(links)})
+
+	return links, nil
+
+}
+
+func withAttachmentLinks(data map[string]any, links []AttachmentLink) map[string]any {
+
+	out := make(map[string]any, len(data)+1)
+
+	for k, v := range data {
+		out[k] = v
+	}
+
+	out[AttachmentLinksTemplateField] = attachmentLinksTemplateValue(links)
+
+	return out
+
+}
+
+func formatByteSize(n int64) string {
+
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+
+}
@@ -0,0 +1,86 @@
+package maileroo
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+type AttachmentChecksum struct {
+	FileName string
+	Size     int64
+	MD5      string
+	SHA256   string
+}
+
+func WithAttachmentChecksums() ClientOption {
+	return func(c *Client) error {
+		c.attachmentChecksums = true
+		return nil
+	}
+}
+
+func (a *Attachment) Checksum() (*AttachmentChecksum, error) {
+
+	loaded, err := a.Load()
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := base64.StdEncoding.DecodeString(loaded.Content)
+
+	if err != nil {
+		return nil, newFieldError("content", FieldErrorInvalid, "invalid base64 content provided")
+	}
+
+	sum := checksumBytes(content)
+	sum.FileName = a.FileName
+
+	return sum, nil
+
+}
+
+func checksumBytes(content []byte) *AttachmentChecksum {
+
+	md := md5.Sum(content)
+	sha := sha256.Sum256(content)
+
+	return &AttachmentChecksum{
+		Size:   int64(len(content)),
+		MD5:    hex.EncodeToString(md[:]),
+		SHA256: hex.EncodeToString(sha[:]),
+	}
+
+}
+
+func (c *Client) attachmentChecksumsFor(payload map[string]any) []AttachmentChecksum {
+
+	if !c.attachmentChecksums {
+		return nil
+	}
+
+	atts, _ := payload["attachments"].([]Attachment)
+
+	if len(atts) == 0 {
+		return nil
+	}
+
+	out := make([]AttachmentChecksum, 0, len(atts))
+
+	for _, att := range atts {
+
+		sum, err := att.Checksum()
+
+		if err != nil {
+			continue
+		}
+
+		out = append(out, *sum)
+
+	}
+
+	return out
+
+}
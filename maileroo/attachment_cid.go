@@ -0,0 +1,65 @@
+package maileroo
+
+import (
+	"fmt"
+)
+
+func (a *Attachment) CID() string {
+
+	if !a.Inline {
+		return ""
+	}
+
+	return a.FileName
+
+}
+
+func (a *Attachment) CIDRef() string {
+
+	if cid := a.CID(); cid != "" {
+		return "cid:" + cid
+	}
+
+	return ""
+
+}
+
+func PartitionAttachments(atts []Attachment) (inline, regular []Attachment) {
+
+	for _, att := range atts {
+
+		if att.Inline {
+			inline = append(inline, att)
+		} else {
+			regular = append(regular, att)
+		}
+
+	}
+
+	return inline, regular
+
+}
+
+// The API identifies inline parts by file name, so two inline attachments
+// with the same name could not be told apart from the HTML.
+func checkInlineFileNames(atts []Attachment) error {
+
+	seen := make(map[string]int, len(atts))
+
+	for i := range atts {
+
+		if !atts[i].Inline {
+			continue
+		}
+
+		if j, ok := seen[atts[i].FileName]; ok {
+			return newFieldError(fmt.Sprintf("attachments[%d].file_name", i), FieldErrorDuplicate, "inline attachments %d and %d share file name %q; rename one to tell them apart", j, i, atts[i].FileName)
+		}
+
+		seen[atts[i].FileName] = i
+
+	}
+
+	return nil
+
+}
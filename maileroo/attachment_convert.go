@@ -0,0 +1,86 @@
+package maileroo
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+type ImageFormat string
+
+const (
+	ImageFormatPNG  ImageFormat = "png"
+	ImageFormatJPEG ImageFormat = "jpeg"
+	ImageFormatGIF  ImageFormat = "gif"
+)
+
+const DefaultJPEGQuality = 90
+
+func AttachmentFromCSVRecords(name string, rows [][]string) (*Attachment, error) {
+
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to encode csv: %w", err)
+	}
+
+	return AttachmentFromContent(name, buf.Bytes(), "text/csv; charset=utf-8", false)
+
+}
+
+func AttachmentFromJSON(name string, v any) (*Attachment, error) {
+
+	data, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode json: %w", err)
+	}
+
+	return AttachmentFromContent(name, data, "application/json", false)
+
+}
+
+func AttachmentFromImage(name string, img image.Image, format ImageFormat) (*Attachment, error) {
+
+	if img == nil {
+		return nil, errors.New("image must not be nil")
+	}
+
+	var buf bytes.Buffer
+	var err error
+	var ct string
+
+	switch format {
+
+	case ImageFormatPNG:
+		ct = "image/png"
+		err = png.Encode(&buf, img)
+
+	case ImageFormatJPEG:
+		ct = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: DefaultJPEGQuality})
+
+	case ImageFormatGIF:
+		ct = "image/gif"
+		err = gif.Encode(&buf, img, nil)
+
+	default:
+		return nil, fmt.Errorf("unsupported image format %q: expected %q, %q or %q", format, ImageFormatPNG, ImageFormatJPEG, ImageFormatGIF)
+
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s image: %w", format, err)
+	}
+
+	return AttachmentFromContent(name, buf.Bytes(), ct, false)
+
+}
@@ -0,0 +1,108 @@
+package maileroo
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var DefaultBlockedExtensions = []string{
+	".ade", ".adp", ".bat", ".chm", ".cmd", ".com", ".cpl", ".exe", ".hta", ".jar", ".js", ".jse",
+	".lnk", ".msc", ".msi", ".msp", ".pif", ".ps1", ".reg", ".scr", ".sct", ".vb", ".vbe", ".vbs", ".wsc", ".wsf", ".wsh",
+}
+
+type AttachmentPolicy struct {
+	MaxAttachments    int
+	BlockedExtensions []string
+	AllowedExtensions []string
+}
+
+func WithAttachmentPolicy(policy AttachmentPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxAttachments < 0 {
+			return errors.New("max attachments must not be negative")
+		}
+		p := policy
+		p.BlockedExtensions = normalizeExtensions(policy.BlockedExtensions)
+		p.AllowedExtensions = normalizeExtensions(policy.AllowedExtensions)
+		c.attachmentPolicy = &p
+		return nil
+	}
+}
+
+func (c *Client) checkAttachmentPolicy(atts []Attachment) error {
+
+	if c.attachmentPolicy == nil {
+		return nil
+	}
+
+	return c.attachmentPolicy.check(atts)
+
+}
+
+func (p *AttachmentPolicy) check(atts []Attachment) error {
+
+	if p.MaxAttachments > 0 && len(atts) > p.MaxAttachments {
+		return newFieldError("attachments", FieldErrorTooMany, "message has %d attachments, exceeding the maximum of %d", len(atts), p.MaxAttachments)
+	}
+
+	for i, att := range atts {
+
+		ext := attachmentExtension(att.FileName)
+
+		if len(p.AllowedExtensions) > 0 && !containsExtension(p.AllowedExtensions, ext) {
+			return newFieldError(fmt.Sprintf("attachments[%d].file_name", i), FieldErrorInvalid, "attachments[%d]: file type %q of %q is not in the allowed list", i, ext, att.FileName)
+		}
+
+		if containsExtension(p.BlockedExtensions, ext) {
+			return newFieldError(fmt.Sprintf("attachments[%d].file_name", i), FieldErrorInvalid, "attachments[%d]: file type %q of %q is blocked", i, ext, att.FileName)
+		}
+
+	}
+
+	return nil
+
+}
+
+func attachmentExtension(name string) string {
+	return strings.ToLower(filepath.Ext(strings.TrimRight(strings.TrimSpace(name), ". ")))
+}
+
+func normalizeExtensions(exts []string) []string {
+
+	out := make([]string, 0, len(exts))
+
+	for _, e := range exts {
+
+		e = strings.ToLower(strings.TrimSpace(e))
+
+		if e == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(e, ".") {
+			e = "." + e
+		}
+
+		out = append(out, e)
+
+	}
+
+	return out
+
+}
+
+func containsExtension(exts []string, ext string) bool {
+
+	for _, e := range exts {
+
+		if e == ext {
+			return true
+		}
+
+	}
+
+	return false
+
+}
@@ -0,0 +1,134 @@
+package maileroo
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const MaxAttachmentBundleSize = 20 << 20
+
+var errBundleTooLarge = fmt.Errorf("zip bundle exceeds the maximum size of %d bytes", MaxAttachmentBundleSize)
+
+type cappedBuffer struct {
+	buf bytes.Buffer
+	max int
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+
+	if b.buf.Len()+len(p) > b.max {
+		return 0, errBundleTooLarge
+	}
+
+	return b.buf.Write(p)
+
+}
+
+func BundleAttachmentsAsZip(name string, atts []Attachment) (*Attachment, error) {
+
+	if strings.TrimSpace(name) == "" {
+		return nil, newFieldError("file_name", FieldErrorRequired, "file_name is required")
+	}
+
+	if len(atts) == 0 {
+		return nil, newFieldError("attachments", FieldErrorRequired, "at least one attachment is required")
+	}
+
+	if !strings.EqualFold(filepath.Ext(name), ".zip") {
+		name += ".zip"
+	}
+
+	out := &cappedBuffer{max: MaxAttachmentBundleSize}
+	zw := zip.NewWriter(out)
+	used := map[string]bool{}
+
+	for i, att := range atts {
+
+		att, err := att.Load()
+
+		if err != nil {
+			return nil, fmt.Errorf("attachments[%d]: %w", i, err)
+		}
+
+		if err := att.validate(); err != nil {
+			return nil, fmt.Errorf("attachments[%d]: %w", i, err)
+		}
+
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     uniqueBundleEntryName(att.FileName, used),
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+
+		if err != nil {
+			return nil, bundleError(err)
+		}
+
+		dec := base64.NewDecoder(base64.StdEncoding, strings.NewReader(att.Content))
+
+		if _, err := io.Copy(w, dec); err != nil {
+			return nil, bundleError(err)
+		}
+
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, bundleError(err)
+	}
+
+	return &Attachment{
+		FileName:    name,
+		ContentType: "application/zip",
+		Content:     base64.StdEncoding.EncodeToString(out.buf.Bytes()),
+		Inline:      false,
+	}, nil
+
+}
+
+func bundleError(err error) error {
+
+	if errors.Is(err, errBundleTooLarge) {
+		return errBundleTooLarge
+	}
+
+	var corrupt base64.CorruptInputError
+
+	if errors.As(err, &corrupt) {
+		return newFieldError("content", FieldErrorInvalid, "invalid base64 content provided")
+	}
+
+	return fmt.Errorf("failed to build zip bundle: %w", err)
+
+}
+
+func uniqueBundleEntryName(name string, used map[string]bool) string {
+
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	if !used[name] {
+		used[name] = true
+		return name
+	}
+
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+
+	for n := 2; ; n++ {
+
+		candidate := fmt.Sprintf("%s (%d)%s", stem, n, ext)
+
+		if !used[candidate] {
+			used[candidate] = true
+			return candidate
+		}
+
+	}
+
+}
@@ -0,0 +1,287 @@
+package maileroo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type bulkGroup struct {
+	templateID *int
+	subject    string
+	indices    []int
+}
+
+type bulkGroupKey struct {
+	templateID int
+	subject    string
+}
+
+func groupBulkMessages(defaultTemplateID *int, messages []BulkMessage) []bulkGroup {
+
+	var groups []bulkGroup
+	byKey := map[bulkGroupKey]int{}
+
+	for i, m := range messages {
+
+		tid := m.TemplateID
+
+		if tid == nil {
+			tid = defaultTemplateID
+		}
+
+		key := bulkGroupKey{templateID: -1, subject: m.Subject}
+
+		if tid != nil {
+			key.templateID = *tid
+		}
+
+		g, ok := byKey[key]
+
+		if !ok {
+			g = len(groups)
+			byKey[key] = g
+			groups = append(groups, bulkGroup{templateID: tid, subject: m.Subject})
+		}
+
+		groups[g].indices = append(groups[g].indices, i)
+
+	}
+
+	return groups
+
+}
+
+func resolveBulkSubjects(subject string, messages []BulkMessage) ([]BulkMessage, error) {
+
+	if len(messages) == 0 {
+		return messages, requireSubject(subject)
+	}
+
+	out := make([]BulkMessage, len(messages))
+
+	for i, m := range messages {
+
+		if m.Subject == "" {
+
+			if err := requireSubject(subject); err != nil {
+				return nil, err
+			}
+
+			m.Subject = subject
+			out[i] = m
+			continue
+
+		}
+
+		s, err := interpolatePlaceholders(fmt.Sprintf("messages[%d].subject", i), m.Subject, m.TemplateData, false)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := requireSubject(s); err != nil {
+			return nil, withFieldPrefix(fmt.Sprintf("messages[%d]", i), err)
+		}
+
+		m.Subject = s
+		out[i] = m
+
+	}
+
+	return out, nil
+
+}
+
+func (c *Client) postBulk(ctx context.Context, payload map[string]any) ([]string, error) {
+
+	msgs, _ := payload["messages"].([]map[string]any)
+
+	body := c.emailRequestBody(payload)
+
+	if probed, err := probePayload(ctx, payload, body); probed {
+		return make([]string, len(msgs)), err
+	}
+
+	c.observeBulkBatch(len(msgs))
+
+	data, err := DoJSON[struct {
+		ReferenceIDs []string `json:"reference_ids"`
+	}](ctx, c, http.MethodPost, "emails/bulk", body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return data.ReferenceIDs, nil
+
+}
+
+type bulkPrecheckedKey struct{}
+
+func bulkPrechecked(ctx context.Context) bool {
+
+	checked, _ := ctx.Value(bulkPrecheckedKey{}).(bool)
+
+	return checked
+
+}
+
+func (c *Client) SendBulkEmailsChunked(ctx context.Context, data BulkEmailData) ([]string, error) {
+	return c.sendBulkChunks(ctx, data, 0, nil, nil)
+}
+
+func (c *Client) sendBulkChunks(ctx context.Context, data BulkEmailData, first int, ids []string, onChunk func(next int, ids []string) error) ([]string, error) {
+
+	if len(data.Messages) == 0 {
+		return nil, newFieldError("messages", FieldErrorRequired, "messages must be a non-empty array")
+	}
+
+	total := (len(data.Messages) + maxBulkMessages - 1) / maxBulkMessages
+
+	if ids == nil {
+		ids = make([]string, 0, len(data.Messages))
+	}
+
+	if err := c.checkLinks(ctx, data.HTML); err != nil {
+		return ids, err
+	}
+
+	c.lintBulkSubjects(ctx, data)
+
+	ctx = context.WithValue(ctx, bulkPrecheckedKey{}, true)
+	ctx = c.withBulkRecipients(ctx)
+
+	pacer := c.newBulkPacer(ctx)
+	delivered := first > 0
+
+	// A checkpoint only records the next chunk to send, so chunks sent after a
+	// failed one in the same wave would be sent again on resume.
+	if onChunk != nil {
+		pacer.maxWidth = 1
+	}
+
+	for i := first; i < total; {
+
+		if err := ctx.Err(); err != nil {
+			return ids, newPartialResult(ids, i, total, err)
+		}
+
+		width, ok := pacer.plan(total - i)
+
+		if !ok {
+			return ids, newPartialResult(ids, i, total, ErrDeadlineTooClose)
+		}
+
+		started := time.Now()
+		results := c.sendBulkWave(ctx, data, i, width)
+
+		pacer.observe(time.Since(started))
+
+		for k, r := range results {
+
+			if r.err == nil {
+				ids = append(ids, r.ids...)
+				delivered = delivered || !r.dropped
+				continue
+			}
+
+			var sent []int
+
+			for j, later := range results[k+1:] {
+
+				if later.err == nil {
+					ids = append(ids, later.ids...)
+					sent = append(sent, i+k+1+j)
+				}
+
+			}
+
+			if len(sent) > 0 {
+				return ids, newPartialResult(ids, i+k+len(sent), total, fmt.Errorf("%w (chunks %v were sent concurrently and are included in the reference IDs)", r.err, sent))
+			}
+
+			return ids, newPartialResult(ids, i+k, total, r.err)
+
+		}
+
+		i += width
+
+		if onChunk != nil {
+
+			if err := onChunk(i, ids); err != nil {
+				return ids, newPartialResult(ids, i, total, fmt.Errorf("chunk %d: failed to save checkpoint: %w", i-1, err))
+			}
+
+		}
+
+	}
+
+	if !delivered {
+		return ids, ErrAllRecipientsDeduplicated
+	}
+
+	return ids, nil
+
+}
+
+func (c *Client) SendToEach(ctx context.Context, data BasicEmailData) ([]string, error) {
+
+	if len(data.To) == 0 {
+		return nil, newFieldError("to", FieldErrorRequired, "field to is required and must have at least one recipient")
+	}
+
+	if len(data.Cc) > 0 || len(data.Bcc) > 0 {
+		return nil, newFieldError("cc", FieldErrorConflict, "cc and bcc cannot be used with SendToEach")
+	}
+
+	if data.ReferenceID != nil {
+		return nil, newFieldError("reference_id", FieldErrorConflict, "reference_id cannot be used with SendToEach; each message gets its own")
+	}
+
+	if data.AMPHTML != nil {
+		return nil, newFieldError("amp_html", FieldErrorConflict, "amp_html cannot be used with SendToEach")
+	}
+
+	if data.HTML == nil && data.Plain == nil {
+		return nil, newFieldError("html", FieldErrorRequired, "either html or plain body is required")
+	}
+
+	if err := data.applyPlaceholders(); err != nil {
+		return nil, err
+	}
+
+	to, err := c.expandGroups(ctx, data.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data.To = to
+
+	messages := make([]BulkMessage, 0, len(data.To))
+
+	for _, r := range data.To {
+		messages = append(messages, BulkMessage{
+			From:        data.From,
+			To:          []EmailAddress{r},
+			ReplyTo:     data.ReplyTo,
+			ScheduledAt: data.ScheduledAt,
+		})
+	}
+
+	return c.SendBulkEmailsChunked(ctx, BulkEmailData{
+		Subject:          data.Subject,
+		HTML:             data.HTML,
+		Plain:            data.Plain,
+		Priority:         data.Priority,
+		Tracking:         data.Tracking,
+		TrackingSettings: data.TrackingSettings,
+		Tags:             data.Tags,
+		Headers:          data.Headers,
+		Attachments:      data.Attachments,
+		Messages:         messages,
+	})
+
+}
@@ -0,0 +1,138 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const MaxBulkConcurrency = 16
+
+var ErrDeadlineTooClose = errors.New("not enough time left before the context deadline to send the next bulk chunk")
+
+type bulkPacer struct {
+	deadline    time.Time
+	hasDeadline bool
+	maxWidth    int
+	waves       int
+	elapsed     time.Duration
+}
+
+func WithBulkConcurrency(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 1 || n > MaxBulkConcurrency {
+			return fmt.Errorf("bulk concurrency must be between 1 and %d", MaxBulkConcurrency)
+		}
+		c.bulkConcurrency = n
+		return nil
+	}
+}
+
+func (c *Client) newBulkPacer(ctx context.Context) *bulkPacer {
+
+	p := &bulkPacer{maxWidth: max(c.bulkConcurrency, 1)}
+
+	p.deadline, p.hasDeadline = ctx.Deadline()
+
+	if isEstimating(ctx) {
+		p.maxWidth = 1
+	}
+
+	return p
+
+}
+
+func (p *bulkPacer) plan(remaining int) (int, bool) {
+
+	if !p.hasDeadline || p.waves == 0 {
+		return 1, true
+	}
+
+	avg := p.elapsed / time.Duration(p.waves)
+	left := time.Until(p.deadline)
+
+	if left < avg {
+		return 0, false
+	}
+
+	for width := 1; width < p.maxWidth; width++ {
+
+		waves := (remaining + width - 1) / width
+
+		if time.Duration(waves)*avg <= left {
+			return width, true
+		}
+
+	}
+
+	return min(p.maxWidth, remaining), true
+
+}
+
+func (p *bulkPacer) observe(d time.Duration) {
+
+	p.waves++
+	p.elapsed += d
+
+}
+
+type bulkChunkResult struct {
+	ids     []string
+	err     error
+	dropped bool
+}
+
+func (c *Client) sendBulkWave(ctx context.Context, data BulkEmailData, first, width int) []bulkChunkResult {
+
+	results := make([]bulkChunkResult, width)
+
+	send := func(k int) {
+
+		start := (first + k) * maxBulkMessages
+		end := min(start+maxBulkMessages, len(data.Messages))
+
+		chunk := data
+		chunk.Messages = data.Messages[start:end]
+
+		ids, err := c.SendBulkEmails(ctx, chunk)
+
+		// Recipients already sent in an earlier chunk can empty a whole chunk;
+		// that only fails the call when no chunk had anything left to send.
+		if errors.Is(err, ErrAllRecipientsDeduplicated) {
+			results[k] = bulkChunkResult{ids: make([]string, len(chunk.Messages)), dropped: true}
+			return
+		}
+
+		if err != nil {
+			err = fmt.Errorf("chunk %d (messages %d-%d): %w", first+k, start, end-1, err)
+		}
+
+		results[k] = bulkChunkResult{ids: ids, err: err}
+
+	}
+
+	if width == 1 {
+		send(0)
+		return results
+	}
+
+	var wg sync.WaitGroup
+
+	for k := 0; k < width; k++ {
+
+		wg.Add(1)
+
+		go func(k int) {
+			defer wg.Done()
+			send(k)
+		}(k)
+
+	}
+
+	wg.Wait()
+
+	return results
+
+}
@@ -0,0 +1,147 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type BulkMessageSource interface {
+	Next(ctx context.Context) (BulkMessage, error)
+}
+
+type BulkMessageSourceFunc func(ctx context.Context) (BulkMessage, error)
+
+func (f BulkMessageSourceFunc) Next(ctx context.Context) (BulkMessage, error) {
+	return f(ctx)
+}
+
+func BulkMessagesFromSlice(messages []BulkMessage) BulkMessageSource {
+
+	i := 0
+
+	return BulkMessageSourceFunc(func(ctx context.Context) (BulkMessage, error) {
+
+		if i >= len(messages) {
+			return BulkMessage{}, io.EOF
+		}
+
+		i++
+
+		return messages[i-1], nil
+
+	})
+
+}
+
+func BulkMessagesFromChannel(ch <-chan BulkMessage) BulkMessageSource {
+	return BulkMessageSourceFunc(func(ctx context.Context) (BulkMessage, error) {
+		select {
+		case m, ok := <-ch:
+			if !ok {
+				return BulkMessage{}, io.EOF
+			}
+			return m, nil
+		case <-ctx.Done():
+			return BulkMessage{}, ctx.Err()
+		}
+	})
+}
+
+func (c *Client) SendBulkEmailsStream(ctx context.Context, data BulkEmailData, src BulkMessageSource) ([]string, error) {
+
+	if src == nil {
+		return nil, errors.New("message source must not be nil")
+	}
+
+	if len(data.Messages) > 0 {
+		return nil, newFieldError("messages", FieldErrorConflict, "messages must be empty when a message source is given")
+	}
+
+	if err := c.checkLinks(ctx, data.HTML); err != nil {
+		return nil, err
+	}
+
+	c.lintBulkSubjects(ctx, data)
+
+	ctx = context.WithValue(ctx, bulkPrecheckedKey{}, true)
+	ctx = c.withBulkRecipients(ctx)
+
+	var ids []string
+	delivered := false
+
+	chunk := make([]BulkMessage, 0, maxBulkMessages)
+
+	for chunks, read := 0, 0; ; chunks++ {
+
+		chunk = chunk[:0]
+		done := false
+
+		for len(chunk) < maxBulkMessages {
+
+			m, err := src.Next(ctx)
+
+			if errors.Is(err, io.EOF) {
+				done = true
+				break
+			}
+
+			if err != nil {
+				return ids, newPartialResult(ids, chunks, 0, fmt.Errorf("failed to read message %d: %w", read, err))
+			}
+
+			chunk = append(chunk, m)
+			read++
+
+		}
+
+		if len(chunk) == 0 {
+
+			if chunks == 0 {
+				return nil, newFieldError("messages", FieldErrorRequired, "messages must be a non-empty array")
+			}
+
+			if !delivered {
+				return ids, ErrAllRecipientsDeduplicated
+			}
+
+			return ids, nil
+
+		}
+
+		if err := ctx.Err(); err != nil {
+			return ids, newPartialResult(ids, chunks, 0, err)
+		}
+
+		send := data
+		send.Messages = chunk
+
+		chunkIDs, err := c.SendBulkEmails(ctx, send)
+
+		if errors.Is(err, ErrAllRecipientsDeduplicated) {
+			chunkIDs, err = make([]string, len(chunk)), nil
+		} else if err == nil {
+			delivered = true
+		}
+
+		if err != nil {
+			first := read - len(chunk)
+			return ids, newPartialResult(ids, chunks, 0, fmt.Errorf("chunk %d (messages %d-%d): %w", chunks, first, read-1, err))
+		}
+
+		ids = append(ids, chunkIDs...)
+
+		if done {
+
+			if !delivered {
+				return ids, ErrAllRecipientsDeduplicated
+			}
+
+			return ids, nil
+
+		}
+
+	}
+
+}
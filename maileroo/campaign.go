@@ -0,0 +1,366 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+type AudienceMember struct {
+	Address      EmailAddress
+	TemplateData map[string]any
+	ReferenceID  *string
+}
+
+type Audience interface {
+	Members(ctx context.Context) ([]AudienceMember, error)
+}
+
+type AudienceFunc func(ctx context.Context) ([]AudienceMember, error)
+
+func (f AudienceFunc) Members(ctx context.Context) ([]AudienceMember, error) {
+	return f(ctx)
+}
+
+func AudienceFromSlice(addrs ...EmailAddress) Audience {
+
+	members := make([]AudienceMember, 0, len(addrs))
+
+	for _, a := range addrs {
+		members = append(members, AudienceMember{Address: a})
+	}
+
+	return AudienceFunc(func(ctx context.Context) ([]AudienceMember, error) {
+		return members, nil
+	})
+
+}
+
+func AudienceFromGroup(g *RecipientGroup) Audience {
+	return AudienceFunc(func(ctx context.Context) ([]AudienceMember, error) {
+		if g == nil {
+			return nil, errors.New("recipient group must not be nil")
+		}
+		return []AudienceMember{{Address: g.Address()}}, nil
+	})
+}
+
+func AudienceFromCSV(r io.Reader) Audience {
+
+	var (
+		once    sync.Once
+		members []AudienceMember
+		err     error
+	)
+
+	return AudienceFunc(func(ctx context.Context) ([]AudienceMember, error) {
+		once.Do(func() { members, err = readAudienceCSV(r) })
+		return members, err
+	})
+
+}
+
+func readAudienceCSV(r io.Reader) ([]AudienceMember, error) {
+
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audience csv header: %w", err)
+	}
+
+	emailCol, nameCol := -1, -1
+
+	for i, h := range header {
+
+		switch strings.ToLower(strings.TrimSpace(h)) {
+
+		case "email", "address":
+			emailCol = i
+
+		case "name", "display_name":
+			nameCol = i
+
+		}
+
+	}
+
+	if emailCol < 0 {
+		return nil, errors.New("audience csv header must have an email column")
+	}
+
+	var members []AudienceMember
+
+	for {
+
+		row, err := cr.Read()
+
+		if err == io.EOF {
+			return members, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audience csv: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		if strings.TrimSpace(row[emailCol]) == "" {
+			return nil, fmt.Errorf("audience csv line %d has no email", line)
+		}
+
+		name := ""
+
+		if nameCol >= 0 {
+			name = row[nameCol]
+		}
+
+		m := AudienceMember{Address: NewEmail(row[emailCol], name)}
+
+		for i, v := range row {
+
+			if i == emailCol || i == nameCol || strings.TrimSpace(header[i]) == "" {
+				continue
+			}
+
+			if m.TemplateData == nil {
+				m.TemplateData = map[string]any{}
+			}
+
+			m.TemplateData[strings.TrimSpace(header[i])] = v
+
+		}
+
+		members = append(members, m)
+
+	}
+
+}
+
+type Campaign struct {
+	Name             string
+	From             EmailAddress
+	ReplyTo          []EmailAddress
+	Subject          string
+	HTML             *string
+	Plain            *string
+	TemplateID       *int
+	Audience         Audience
+	Tags             AssocMap
+	Headers          AssocMap
+	Attachments      []Attachment
+	ScheduledAt      *time.Time
+	TrackingSettings *TrackingSettings
+}
+
+type CampaignResult struct {
+	ReferenceIDs []string
+	Recipients   int
+	Duplicates   int
+}
+
+type CampaignCost struct {
+	Recipients int
+	Requests   int
+	UnitPrice  int64
+	Total      int64
+}
+
+func (cp *Campaign) Validate() error {
+
+	if strings.TrimSpace(cp.From.Address) == "" {
+		return newFieldError("from", FieldErrorRequired, "from must be a valid email address")
+	}
+
+	if cp.Audience == nil {
+		return newFieldError("audience", FieldErrorRequired, "audience is required")
+	}
+
+	hasBody := cp.HTML != nil || cp.Plain != nil
+
+	if !hasBody && cp.TemplateID == nil {
+		return newFieldError("template_id", FieldErrorRequired, "you must provide either html, plain, or template_id")
+	}
+
+	if hasBody && cp.TemplateID != nil {
+		return newFieldError("template_id", FieldErrorConflict, "template_id cannot be combined with html or plain")
+	}
+
+	if err := requireSubject(cp.Subject); err != nil {
+		return err
+	}
+
+	if _, err := resolveTracking(nil, cp.TrackingSettings); err != nil {
+		return err
+	}
+
+	return nil
+
+}
+
+func (cp *Campaign) EstimateSize(ctx context.Context, c *Client) (*PayloadEstimate, error) {
+
+	data, _, err := cp.bulkData(ctx, c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.EstimatePayloadSize(ctx, data)
+
+}
+
+func (cp *Campaign) EstimateCost(ctx context.Context, c *Client, unitPrice int64) (*CampaignCost, error) {
+
+	if unitPrice < 0 {
+		return nil, errors.New("unit price must not be negative")
+	}
+
+	data, _, err := cp.bulkData(ctx, c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(data.Messages)
+
+	return &CampaignCost{
+		Recipients: n,
+		Requests:   (n + maxBulkMessages - 1) / maxBulkMessages,
+		UnitPrice:  unitPrice,
+		Total:      int64(n) * unitPrice,
+	}, nil
+
+}
+
+func (cp *Campaign) Send(ctx context.Context, c *Client) (*CampaignResult, error) {
+
+	data, dupes, err := cp.bulkData(ctx, c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CampaignResult{Recipients: len(data.Messages), Duplicates: dupes}
+
+	ids, err := c.SendBulkEmailsChunked(ctx, data)
+
+	result.ReferenceIDs = ids
+
+	if err != nil {
+		return result, cp.wrapErr(err)
+	}
+
+	return result, nil
+
+}
+
+func (cp *Campaign) wrapErr(err error) error {
+
+	if cp.Name == "" {
+		return err
+	}
+
+	return fmt.Errorf("campaign %q: %w", cp.Name, err)
+
+}
+
+func (cp *Campaign) bulkData(ctx context.Context, c *Client) (BulkEmailData, int, error) {
+
+	if c == nil {
+		return BulkEmailData{}, 0, errors.New("client must not be nil")
+	}
+
+	if err := cp.Validate(); err != nil {
+		return BulkEmailData{}, 0, cp.wrapErr(err)
+	}
+
+	members, err := cp.Audience.Members(ctx)
+
+	if err != nil {
+		return BulkEmailData{}, 0, cp.wrapErr(fmt.Errorf("failed to load audience: %w", err))
+	}
+
+	expanded, err := c.expandAudience(ctx, members)
+
+	if err != nil {
+		return BulkEmailData{}, 0, cp.wrapErr(err)
+	}
+
+	if len(expanded) == 0 {
+		return BulkEmailData{}, 0, cp.wrapErr(newFieldError("audience", FieldErrorRequired, "audience has no members"))
+	}
+
+	seen := map[string]bool{}
+	messages := make([]BulkMessage, 0, len(expanded))
+
+	for _, m := range expanded {
+
+		key := strings.ToLower(strings.TrimSpace(m.Address.Address))
+
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		messages = append(messages, BulkMessage{
+			From:         cp.From,
+			To:           []EmailAddress{m.Address},
+			ReplyTo:      cp.ReplyTo,
+			ReferenceID:  m.ReferenceID,
+			TemplateData: m.TemplateData,
+			ScheduledAt:  cp.ScheduledAt,
+		})
+
+	}
+
+	data := BulkEmailData{
+		Subject:          cp.Subject,
+		HTML:             cp.HTML,
+		Plain:            cp.Plain,
+		TemplateID:       cp.TemplateID,
+		Tags:             cp.Tags,
+		Headers:          cp.Headers,
+		Attachments:      cp.Attachments,
+		Messages:         messages,
+		TrackingSettings: cp.TrackingSettings,
+	}
+
+	return data, len(expanded) - len(messages), nil
+
+}
+
+func (c *Client) expandAudience(ctx context.Context, members []AudienceMember) ([]AudienceMember, error) {
+
+	out := make([]AudienceMember, 0, len(members))
+
+	for i, m := range members {
+
+		if m.Address.group == nil {
+			out = append(out, m)
+			continue
+		}
+
+		addrs, err := c.expandGroups(ctx, []EmailAddress{m.Address})
+
+		if err != nil {
+			return nil, withFieldPrefix(fmt.Sprintf("audience[%d]", i), err)
+		}
+
+		for _, a := range addrs {
+			out = append(out, AudienceMember{Address: a, TemplateData: m.TemplateData})
+		}
+
+	}
+
+	return out, nil
+
+}
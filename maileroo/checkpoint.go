@@ -0,0 +1,112 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type BulkCheckpoint struct {
+	NextChunk     int
+	TotalMessages int
+	ReferenceIDs  []string
+}
+
+type CheckpointStore interface {
+	Load(ctx context.Context, key string) (*BulkCheckpoint, error)
+	Save(ctx context.Context, key string, cp BulkCheckpoint) error
+}
+
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]BulkCheckpoint
+}
+
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]BulkCheckpoint{}}
+}
+
+func (s *MemoryCheckpointStore) Load(ctx context.Context, key string) (*BulkCheckpoint, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp, ok := s.checkpoints[key]
+
+	if !ok {
+		return nil, nil
+	}
+
+	cp.ReferenceIDs = append([]string(nil), cp.ReferenceIDs...)
+
+	return &cp, nil
+
+}
+
+func (s *MemoryCheckpointStore) Save(ctx context.Context, key string, cp BulkCheckpoint) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp.ReferenceIDs = append([]string(nil), cp.ReferenceIDs...)
+	s.checkpoints[key] = cp
+
+	return nil
+
+}
+
+func (c *Client) SendBulkEmailsResumable(ctx context.Context, data BulkEmailData, key string, store CheckpointStore) ([]string, error) {
+
+	if strings.TrimSpace(key) == "" {
+		return nil, errors.New("checkpoint key must be a non-empty string")
+	}
+
+	if store == nil {
+		return nil, errors.New("checkpoint store must not be nil")
+	}
+
+	if len(data.Messages) == 0 {
+		return nil, newFieldError("messages", FieldErrorRequired, "messages must be a non-empty array")
+	}
+
+	total := (len(data.Messages) + maxBulkMessages - 1) / maxBulkMessages
+
+	cp, err := store.Load(ctx, key)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint %q: %w", key, err)
+	}
+
+	first := 0
+	var ids []string
+
+	if cp != nil {
+
+		if cp.TotalMessages != len(data.Messages) {
+			return nil, fmt.Errorf("checkpoint %q was saved for %d messages, not %d", key, cp.TotalMessages, len(data.Messages))
+		}
+
+		if cp.NextChunk < 0 || cp.NextChunk > total {
+			return nil, fmt.Errorf("checkpoint %q has an invalid chunk index %d", key, cp.NextChunk)
+		}
+
+		if cp.NextChunk == total {
+			return cp.ReferenceIDs, nil
+		}
+
+		first = cp.NextChunk
+		ids = append(make([]string, 0, len(data.Messages)), cp.ReferenceIDs...)
+
+	}
+
+	return c.sendBulkChunks(ctx, data, first, ids, func(next int, ids []string) error {
+		return store.Save(ctx, key, BulkCheckpoint{
+			NextChunk:     next,
+			TotalMessages: len(data.Messages),
+			ReferenceIDs:  ids,
+		})
+	})
+
+}
@@ -1,6 +1,8 @@
 package maileroo
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
@@ -8,19 +10,67 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	mathrand "math/rand"
 	"net/http"
 	"net/url"
+	"reflect"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 type Client struct {
-	apiBaseURL string
-	APIKey     string
-	Timeout    time.Duration
-	http       *http.Client
+	apiBaseURL           string
+	APIKey               string
+	Timeout              time.Duration
+	http                 *http.Client
+	compress             bool
+	compressionThreshold int
+	observers            []Observer
+	contextTaggers       []ContextTagger
+	logger               *slog.Logger
+	addressRedaction     AddressRedaction
+	maxRecipients        int
+	recipientLimits      RecipientLimits
+	normalization        *NormalizationOptions
+	failoverURLs         []string
+	failoverThreshold    int
+	failoverCooldown     time.Duration
+	failover             *failoverPool
+	connectTimeout       time.Duration
+	readTimeout          time.Duration
+	maxResponseSize      int64
+	userAgent            string
+	appInfo              []string
+	disableTelemetry     bool
+	suppressionChecker   SuppressionChecker
+	complaintPolicy      *ComplaintPolicy
+	retryPolicy          *RetryPolicy
+	bulkConcurrency      int
+	utmParams            url.Values
+	linkCheck            *LinkCheckPolicy
+	lifecycle            *lifecycle
+	subjectLint          *SubjectLintOptions
+	attachmentPolicy     *AttachmentPolicy
+	attachmentBudget     *AttachmentBudget
+	domainVerifier       *domainVerifier
+	htmlSanitizer        HTMLSanitizer
+	defaultFrom          *EmailAddress
+	defaultTags          AssocMap
+	defaultReplyTo       []EmailAddress
+	defaultTracking      *bool
+	defaultHeaders       AssocMap
+	attachmentChecksums  bool
+	encodedWords         bool
+	referenceIDGenerator ReferenceIDGenerator
+	operationTimeouts    OperationTimeouts
+	responseCache        ResponseCache
+	responseCacheTTL     time.Duration
+	maxRequestSize       int64
+	contentLint          ContentLintMode
+	roundTrippers        []RoundTripperMiddleware
 }
 
 const (
@@ -29,6 +79,7 @@ const (
 	MaxAssociativeMapValueLength = 768
 	MaxSubjectLength             = 255
 	ReferenceIDLength            = 24 // hex chars
+	DefaultCompressionThreshold  = 64 * 1024
 	maxBulkMessages              = 500
 	defaultUserAgent             = "maileroo-go-sdk/1.0"
 )
@@ -37,37 +88,43 @@ type AssocValue = any
 type AssocMap = map[string]AssocValue
 
 type BasicEmailData struct {
-	From        EmailAddress   `json:"-"`
-	To          []EmailAddress `json:"-"`
-	Cc          []EmailAddress `json:"-"`
-	Bcc         []EmailAddress `json:"-"`
-	ReplyTo     []EmailAddress `json:"-"`
-	Subject     string         `json:"-"`
-	HTML        *string        `json:"-"`
-	Plain       *string        `json:"-"`
-	Tracking    *bool          `json:"-"`
-	Tags        AssocMap       `json:"-"`
-	Headers     AssocMap       `json:"-"`
-	Attachments []Attachment   `json:"-"`
-	ScheduledAt *time.Time     `json:"-"`
-	ReferenceID *string        `json:"-"`
+	From             EmailAddress      `json:"-"`
+	To               []EmailAddress    `json:"-"`
+	Cc               []EmailAddress    `json:"-"`
+	Bcc              []EmailAddress    `json:"-"`
+	ReplyTo          []EmailAddress    `json:"-"`
+	Subject          string            `json:"-"`
+	HTML             *string           `json:"-"`
+	Plain            *string           `json:"-"`
+	AMPHTML          *string           `json:"-"`
+	Priority         Priority          `json:"-"`
+	Tracking         *bool             `json:"-"`
+	Tags             AssocMap          `json:"-"`
+	Headers          AssocMap          `json:"-"`
+	Attachments      []Attachment      `json:"-"`
+	ScheduledAt      *time.Time        `json:"-"`
+	ReferenceID      *string           `json:"-"`
+	PlaceholderData  map[string]any    `json:"-"`
+	TrackingSettings *TrackingSettings `json:"-"`
 }
 
 type TemplatedEmailData struct {
-	From         EmailAddress   `json:"-"`
-	To           []EmailAddress `json:"-"`
-	Cc           []EmailAddress `json:"-"`
-	Bcc          []EmailAddress `json:"-"`
-	ReplyTo      []EmailAddress `json:"-"`
-	Subject      string         `json:"-"`
-	TemplateID   int            `json:"-"`
-	TemplateData map[string]any `json:"-"`
-	Tracking     *bool          `json:"-"`
-	Tags         AssocMap       `json:"-"`
-	Headers      AssocMap       `json:"-"`
-	Attachments  []Attachment   `json:"-"`
-	ScheduledAt  *time.Time     `json:"-"`
-	ReferenceID  *string        `json:"-"`
+	From             EmailAddress      `json:"-"`
+	To               []EmailAddress    `json:"-"`
+	Cc               []EmailAddress    `json:"-"`
+	Bcc              []EmailAddress    `json:"-"`
+	ReplyTo          []EmailAddress    `json:"-"`
+	Subject          string            `json:"-"`
+	TemplateID       int               `json:"-"`
+	TemplateData     map[string]any    `json:"-"`
+	Priority         Priority          `json:"-"`
+	Tracking         *bool             `json:"-"`
+	Tags             AssocMap          `json:"-"`
+	Headers          AssocMap          `json:"-"`
+	Attachments      []Attachment      `json:"-"`
+	ScheduledAt      *time.Time        `json:"-"`
+	ReferenceID      *string           `json:"-"`
+	TrackingSettings *TrackingSettings `json:"-"`
 }
 
 type BulkMessage struct {
@@ -76,20 +133,25 @@ type BulkMessage struct {
 	Cc           []EmailAddress `json:"-"`
 	Bcc          []EmailAddress `json:"-"`
 	ReplyTo      []EmailAddress `json:"-"`
+	Subject      string         `json:"-"`
 	ReferenceID  *string        `json:"-"`
+	TemplateID   *int           `json:"-"`
 	TemplateData map[string]any `json:"-"`
+	ScheduledAt  *time.Time     `json:"-"`
 }
 
 type BulkEmailData struct {
-	Subject     string        `json:"-"`
-	HTML        *string       `json:"-"`
-	Plain       *string       `json:"-"`
-	TemplateID  *int          `json:"-"`
-	Tracking    *bool         `json:"-"`
-	Tags        AssocMap      `json:"-"`
-	Headers     AssocMap      `json:"-"`
-	Attachments []Attachment  `json:"-"`
-	Messages    []BulkMessage `json:"-"`
+	Subject          string            `json:"-"`
+	HTML             *string           `json:"-"`
+	Plain            *string           `json:"-"`
+	TemplateID       *int              `json:"-"`
+	Priority         Priority          `json:"-"`
+	Tracking         *bool             `json:"-"`
+	Tags             AssocMap          `json:"-"`
+	Headers          AssocMap          `json:"-"`
+	Attachments      []Attachment      `json:"-"`
+	Messages         []BulkMessage     `json:"-"`
+	TrackingSettings *TrackingSettings `json:"-"`
 }
 
 type ScheduledEmailsResponse struct {
@@ -107,6 +169,7 @@ type BasePayload struct {
 	Cc          []EmailAddress
 	Bcc         []EmailAddress
 	ReplyTo     []EmailAddress
+	Priority    Priority
 	Tracking    *bool
 	Tags        AssocMap
 	Headers     AssocMap
@@ -130,6 +193,17 @@ func WithAPIBaseURL(url string) ClientOption {
 	}
 }
 
+func WithCompression(thresholdBytes int) ClientOption {
+	return func(c *Client) error {
+		if thresholdBytes < 0 {
+			return errors.New("compression threshold must not be negative")
+		}
+		c.compress = true
+		c.compressionThreshold = thresholdBytes
+		return nil
+	}
+}
+
 func NewClient(apiKey string, timeoutSeconds int, opts ...ClientOption) (*Client, error) {
 	if strings.TrimSpace(apiKey) == "" {
 		return nil, errors.New("API key must be a non-empty string")
@@ -140,12 +214,12 @@ func NewClient(apiKey string, timeoutSeconds int, opts ...ClientOption) (*Client
 	}
 
 	client := &Client{
-		apiBaseURL: DefaultAPIBaseURL,
-		APIKey:     apiKey,
-		Timeout:    time.Duration(timeoutSeconds) * time.Second,
-		http: &http.Client{
-			Timeout: time.Duration(timeoutSeconds) * time.Second,
-		},
+		apiBaseURL:      DefaultAPIBaseURL,
+		APIKey:          apiKey,
+		Timeout:         time.Duration(timeoutSeconds) * time.Second,
+		http:            &http.Client{},
+		maxResponseSize: DefaultMaxResponseSize,
+		lifecycle:       newLifecycle(),
 	}
 
 	for _, opt := range opts {
@@ -154,12 +228,22 @@ func NewClient(apiKey string, timeoutSeconds int, opts ...ClientOption) (*Client
 		}
 	}
 
+	client.http.Transport = client.buildTransport()
+
+	if len(client.failoverURLs) > 0 {
+		client.failover = newFailoverPool(client.apiBaseURL, client.failoverURLs, client.failoverThreshold, client.failoverCooldown)
+	}
+
 	return client, nil
 
 }
 
 func (c *Client) GetReferenceID() string {
 
+	if c.referenceIDGenerator != nil {
+		return c.referenceIDGenerator.GenerateReferenceID()
+	}
+
 	const byteLen = ReferenceIDLength / 2
 
 	b := make([]byte, byteLen)
@@ -179,6 +263,48 @@ func (c *Client) GetReferenceID() string {
 
 func (c *Client) SendBasicEmail(ctx context.Context, data BasicEmailData) (string, error) {
 
+	res, err := c.SendBasicEmailResult(ctx, data)
+
+	if err != nil {
+		return "", err
+	}
+
+	return res.ReferenceID, nil
+
+}
+
+func (c *Client) SendBasicEmailResult(ctx context.Context, data BasicEmailData) (*SendResult, error) {
+
+	if err := data.applyPlaceholders(); err != nil {
+		return nil, err
+	}
+
+	html, err := c.sanitizeHTMLBody(data.HTML)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data.HTML = html
+
+	if err := c.lintContent(ctx, data.Subject, data.HTML, data.Plain); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkLinks(ctx, data.HTML); err != nil {
+		return nil, err
+	}
+
+	lint := c.lintSubject(ctx, data.Subject)
+
+	tracking, err := resolveTracking(data.Tracking, data.TrackingSettings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data.HTML = tagLinksWithUTM(data.HTML, c.utmParamsFor(data.TrackingSettings))
+
 	payload := BasePayload{
 		Subject:     data.Subject,
 		From:        data.From,
@@ -186,7 +312,8 @@ func (c *Client) SendBasicEmail(ctx context.Context, data BasicEmailData) (strin
 		Cc:          data.Cc,
 		Bcc:         data.Bcc,
 		ReplyTo:     data.ReplyTo,
-		Tracking:    data.Tracking,
+		Priority:    data.Priority,
+		Tracking:    tracking,
 		Tags:        data.Tags,
 		Headers:     data.Headers,
 		Attachments: data.Attachments,
@@ -194,45 +321,87 @@ func (c *Client) SendBasicEmail(ctx context.Context, data BasicEmailData) (strin
 		ReferenceID: data.ReferenceID,
 	}
 
-	basePayload, err := c.buildBasePayload(payload)
+	skipped, err := c.applyComplaintPolicy(ctx, &payload)
 
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	basePayload, err := c.buildBasePayload(ctx, payload)
+
+	if err != nil {
+		return nil, err
 	}
 
 	if data.HTML == nil && data.Plain == nil {
-		return "", errors.New("either html or plain body is required")
+		return nil, newFieldError("html", FieldErrorRequired, "either html or plain body is required")
 	}
 
-	basePayload["html"] = data.HTML
-	basePayload["plain"] = data.Plain
+	links, err := c.offloadAttachments(ctx, basePayload)
 
-	var out struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-		Data    struct {
-			ReferenceID string `json:"reference_id"`
-		} `json:"data"`
+	if err != nil {
+		return nil, err
 	}
 
-	if err := c.sendRequest(ctx, http.MethodPost, "emails", basePayload, &out); err != nil {
-		return "", err
+	data.HTML, data.Plain = appendAttachmentLinks(data.HTML, data.Plain, links)
+
+	if data.HTML != nil {
+		basePayload["html"] = *data.HTML
 	}
 
-	if out.Success {
-		return out.Data.ReferenceID, nil
+	if data.Plain != nil {
+		basePayload["plain"] = *data.Plain
 	}
 
-	if out.Message == "" {
-		out.Message = "Unknown"
+	if data.AMPHTML != nil {
+
+		if err := validateAMPHTML(*data.AMPHTML, data.HTML); err != nil {
+			return nil, err
+		}
+
+		basePayload["amp_html"] = *data.AMPHTML
+
+	}
+
+	res, err := c.postEmail(ctx, "emails", basePayload, data.ScheduledAt)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return "", fmt.Errorf("the API returned an error: %s", out.Message)
+	res.Skipped = skipped
+	res.Lint = lint
+
+	return res, nil
 
 }
 
 func (c *Client) SendTemplatedEmail(ctx context.Context, data TemplatedEmailData) (string, error) {
 
+	res, err := c.SendTemplatedEmailResult(ctx, data)
+
+	if err != nil {
+		return "", err
+	}
+
+	return res.ReferenceID, nil
+
+}
+
+func (c *Client) SendTemplatedEmailResult(ctx context.Context, data TemplatedEmailData) (*SendResult, error) {
+
+	tracking, err := resolveTracking(data.Tracking, data.TrackingSettings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.TrackingSettings.utmParams()) > 0 {
+		return nil, newFieldError("tracking_settings", FieldErrorConflict, "utm parameters can only be added to html bodies; add them to the template's links instead")
+	}
+
+	lint := c.lintSubject(ctx, data.Subject)
+
 	payload := BasePayload{
 		Subject:     data.Subject,
 		From:        data.From,
@@ -240,7 +409,8 @@ func (c *Client) SendTemplatedEmail(ctx context.Context, data TemplatedEmailData
 		Cc:          data.Cc,
 		Bcc:         data.Bcc,
 		ReplyTo:     data.ReplyTo,
-		Tracking:    data.Tracking,
+		Priority:    data.Priority,
+		Tracking:    tracking,
 		Tags:        data.Tags,
 		Headers:     data.Headers,
 		Attachments: data.Attachments,
@@ -248,10 +418,16 @@ func (c *Client) SendTemplatedEmail(ctx context.Context, data TemplatedEmailData
 		ReferenceID: data.ReferenceID,
 	}
 
-	basePayload, err := c.buildBasePayload(payload)
+	skipped, err := c.applyComplaintPolicy(ctx, &payload)
 
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	basePayload, err := c.buildBasePayload(ctx, payload)
+
+	if err != nil {
+		return nil, err
 	}
 
 	basePayload["template_id"] = data.TemplateID
@@ -259,61 +435,154 @@ func (c *Client) SendTemplatedEmail(ctx context.Context, data TemplatedEmailData
 	if data.TemplateData != nil {
 
 		if err := validateTemplateData(data.TemplateData); err != nil {
-			return "", err
+			return nil, err
 		}
 
 		basePayload["template_data"] = data.TemplateData
 
 	}
 
-	var out struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-		Data    struct {
-			ReferenceID string `json:"reference_id"`
-		} `json:"data"`
-	}
+	links, err := c.offloadAttachments(ctx, basePayload)
 
-	if err := c.sendRequest(ctx, http.MethodPost, "emails/template", basePayload, &out); err != nil {
-		return "", err
+	if err != nil {
+		return nil, err
 	}
 
-	if out.Success {
-		return out.Data.ReferenceID, nil
+	if len(links) > 0 {
+		basePayload["template_data"] = withAttachmentLinks(data.TemplateData, links)
 	}
 
-	if out.Message == "" {
-		out.Message = "Unknown"
+	res, err := c.postEmail(ctx, "emails/template", basePayload, data.ScheduledAt)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return "", fmt.Errorf("the API returned an error: %s", out.Message)
+	res.Skipped = skipped
+	res.Lint = lint
+
+	return res, nil
 
 }
 
 func (c *Client) SendBulkEmails(ctx context.Context, data BulkEmailData) ([]string, error) {
 
-	if err := requireSubject(data.Subject); err != nil {
+	tracking, err := resolveTracking(data.Tracking, data.TrackingSettings)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data.Tracking = tracking
+
+	c.applyBulkDefaults(&data)
+
+	subjected, err := resolveBulkSubjects(data.Subject, data.Messages)
+
+	if err != nil {
 		return nil, err
 	}
 
+	data.Messages = subjected
+
+	c.lintBulkSubjects(ctx, data)
+
 	hasHTML := data.HTML != nil
 	hasPlain := data.Plain != nil
 	hasTemplateID := data.TemplateID != nil
 
+	perMessageTemplate := false
+
+	for i, m := range data.Messages {
+
+		if m.TemplateID == nil {
+			continue
+		}
+
+		if hasHTML || hasPlain {
+			return nil, newFieldError(fmt.Sprintf("messages[%d].template_id", i), FieldErrorConflict, "messages[%d].template_id cannot be combined with html or plain", i)
+		}
+
+		perMessageTemplate = true
+
+	}
+
+	if perMessageTemplate && !hasTemplateID {
+
+		for i, m := range data.Messages {
+
+			if m.TemplateID == nil {
+				return nil, newFieldError(fmt.Sprintf("messages[%d].template_id", i), FieldErrorRequired, "messages[%d].template_id is required when no default template_id is provided", i)
+			}
+
+		}
+
+		hasTemplateID = true
+
+	}
+
 	if (!hasHTML && !hasPlain) && !hasTemplateID {
-		return nil, errors.New("you must provide either html, plain, or template_id")
+		return nil, newFieldError("template_id", FieldErrorRequired, "you must provide either html, plain, or template_id")
 	}
 
 	if data.TemplateID != nil && (hasHTML || hasPlain) {
-		return nil, errors.New("template_id cannot be combined with html or plain")
+		return nil, newFieldError("template_id", FieldErrorConflict, "template_id cannot be combined with html or plain")
+	}
+
+	html, err := c.sanitizeHTMLBody(data.HTML)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data.HTML = html
+
+	if err := c.lintContent(ctx, data.Subject, data.HTML, data.Plain); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkLinks(ctx, data.HTML); err != nil {
+		return nil, err
+	}
+
+	data.HTML = tagLinksWithUTM(data.HTML, c.utmParamsFor(data.TrackingSettings))
+
+	messages, err := c.expandBulkGroups(ctx, data.Messages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data.Messages = messages
+
+	for i, m := range data.Messages {
+
+		if err := c.verifyFromDomain(ctx, m.From); err != nil {
+			return nil, withFieldPrefix(fmt.Sprintf("messages[%d]", i), err)
+		}
+
 	}
 
 	if len(data.Messages) == 0 {
-		return nil, errors.New("messages must be a non-empty array")
+		return nil, newFieldError("messages", FieldErrorRequired, "messages must be a non-empty array")
 	}
 
 	if len(data.Messages) > maxBulkMessages {
-		return nil, fmt.Errorf("messages cannot contain more than %d items", maxBulkMessages)
+		return nil, newFieldError("messages", FieldErrorTooMany, "messages cannot contain more than %d items", maxBulkMessages)
+	}
+
+	requested := len(data.Messages)
+
+	var kept []int
+
+	if c.normalization != nil {
+
+		data.Messages, kept = c.normalizeBulkRecipients(ctx, data.Messages)
+
+		if len(data.Messages) == 0 {
+			return nil, ErrAllRecipientsDeduplicated
+		}
+
 	}
 
 	payload := map[string]any{
@@ -328,10 +597,6 @@ func (c *Client) SendBulkEmails(ctx context.Context, data BulkEmailData) ([]stri
 		payload["plain"] = data.Plain
 	}
 
-	if hasTemplateID {
-		payload["template_id"] = data.TemplateID
-	}
-
 	if data.Tracking != nil {
 		payload["tracking"] = *data.Tracking
 	}
@@ -346,30 +611,54 @@ func (c *Client) SendBulkEmails(ctx context.Context, data BulkEmailData) ([]stri
 
 	}
 
-	if data.Headers != nil {
+	headers, err := applyPriority(data.Headers, data.Priority)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if headers != nil {
 
-		if err := validateAssociativeMap(data.Headers, "headers"); err != nil {
+		if err := validateAssociativeMap(headers, "headers"); err != nil {
 			return nil, err
 		}
 
-		payload["headers"] = data.Headers
+		if err := validateWellKnownHeaders(headers); err != nil {
+			return nil, err
+		}
+
+		payload["headers"] = headers
+
+	}
 
+	if err := c.checkAttachmentPolicy(data.Attachments); err != nil {
+		return nil, err
 	}
 
 	if len(data.Attachments) > 0 {
 
 		arr := make([]Attachment, 0, len(data.Attachments))
 
-		for _, att := range data.Attachments {
+		for i, att := range data.Attachments {
+
+			att, err := att.Load()
+
+			if err != nil {
+				return nil, withFieldPrefix(fmt.Sprintf("attachments[%d]", i), err)
+			}
 
 			if err := att.validate(); err != nil {
-				return nil, err
+				return nil, withFieldPrefix(fmt.Sprintf("attachments[%d]", i), err)
 			}
 
 			arr = append(arr, att)
 
 		}
 
+		if err := checkInlineFileNames(arr); err != nil {
+			return nil, err
+		}
+
 		payload["attachments"] = arr
 
 	}
@@ -380,73 +669,128 @@ func (c *Client) SendBulkEmails(ctx context.Context, data BulkEmailData) ([]stri
 		return nil, err
 	}
 
-	payload["messages"] = msgs
+	links, err := c.offloadAttachments(ctx, payload)
 
-	var out struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
-		Data    struct {
-			ReferenceIDs []string `json:"reference_ids"`
-		} `json:"data"`
-	}
-
-	if err := c.sendRequest(ctx, http.MethodPost, "emails/bulk", payload, &out); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	if out.Success {
-		return out.Data.ReferenceIDs, nil
-	}
+	if len(links) > 0 {
 
-	if out.Message == "" {
-		out.Message = "Unknown"
-	}
+		if hasTemplateID {
 
-	return nil, fmt.Errorf("the API returned an error: %s", out.Message)
+			for i, m := range data.Messages {
+				msgs[i]["template_data"] = withAttachmentLinks(m.TemplateData, links)
+			}
 
-}
+		} else {
 
-func (c *Client) DeleteScheduledEmail(ctx context.Context, referenceID string) error {
+			data.HTML, data.Plain = appendAttachmentLinks(data.HTML, data.Plain, links)
 
-	if err := validateReferenceID(referenceID); err != nil {
-		return err
-	}
+			if hasHTML {
+				payload["html"] = data.HTML
+			}
+
+			if hasPlain {
+				payload["plain"] = data.Plain
+			}
+
+		}
 
-	var out struct {
-		Success bool   `json:"success"`
-		Message string `json:"message"`
 	}
 
-	path := "emails/scheduled/" + referenceID
+	groups := groupBulkMessages(data.TemplateID, data.Messages)
+	ids := make([]string, len(msgs))
+	var sent []string
+
+	for gi, g := range groups {
+
+		if gi > 0 {
+
+			if err := ctx.Err(); err != nil {
+				return nil, newPartialResult(sent, gi, len(groups), err)
+			}
+
+		}
+
+		groupPayload := make(map[string]any, len(payload)+2)
+
+		for k, v := range payload {
+			groupPayload[k] = v
+		}
+
+		groupPayload["subject"] = g.subject
+
+		if g.templateID != nil {
+			groupPayload["template_id"] = *g.templateID
+		}
+
+		groupMsgs := make([]map[string]any, 0, len(g.indices))
+
+		for _, idx := range g.indices {
+			groupMsgs = append(groupMsgs, msgs[idx])
+		}
+
+		groupPayload["messages"] = groupMsgs
+
+		groupIDs, err := c.postBulk(ctx, groupPayload)
+
+		if err != nil {
+			return nil, newPartialResult(sent, gi, len(groups), err)
+		}
+
+		sent = append(sent, groupIDs...)
+
+		for k, idx := range g.indices {
+
+			if k < len(groupIDs) {
+				ids[idx] = groupIDs[k]
+			}
+
+		}
 
-	if err := c.sendRequest(ctx, http.MethodDelete, path, nil, &out); err != nil {
-		return err
 	}
 
-	if out.Success {
-		return nil
+	if kept != nil && len(kept) < requested {
+
+		positioned := make([]string, requested)
+
+		for k, idx := range kept {
+			positioned[idx] = ids[k]
+		}
+
+		ids = positioned
+
 	}
 
-	if out.Message == "" {
-		out.Message = "Unknown"
+	return ids, nil
+
+}
+
+func (c *Client) DeleteScheduledEmail(ctx context.Context, referenceID string) error {
+
+	if err := validateReferenceID(referenceID); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("the API returned an error: %s", out.Message)
+	_, err := DoJSON[json.RawMessage](ctx, c, http.MethodDelete, "emails/scheduled/"+referenceID, nil)
+
+	return err
 
 }
 
-func (c *Client) GetScheduledEmails(ctx context.Context, page, perPage int) (*ScheduledEmailsResponse, error) {
+func (c *Client) GetScheduledEmails(ctx context.Context, page, perPage int, sort ...ScheduledEmailSort) (*ScheduledEmailsResponse, error) {
 
 	if page < 1 {
-		return nil, errors.New("page must be a positive integer (>= 1)")
+		return nil, newFieldError("page", FieldErrorInvalid, "page must be a positive integer (>= 1)")
 	}
 
 	if perPage < 1 {
-		return nil, errors.New("per_page must be a positive integer (>= 1)")
+		return nil, newFieldError("per_page", FieldErrorInvalid, "per_page must be a positive integer (>= 1)")
 	}
 
 	if perPage > 100 {
-		return nil, errors.New("per_page cannot be greater than 100")
+		return nil, newFieldError("per_page", FieldErrorInvalid, "per_page cannot be greater than 100")
 	}
 
 	q := url.Values{}
@@ -454,39 +798,57 @@ func (c *Client) GetScheduledEmails(ctx context.Context, page, perPage int) (*Sc
 	q.Set("page", fmt.Sprintf("%d", page))
 	q.Set("per_page", fmt.Sprintf("%d", perPage))
 
-	var out struct {
-		Success bool                     `json:"success"`
-		Message string                   `json:"message"`
-		Data    *ScheduledEmailsResponse `json:"data"`
-	}
-
-	if err := c.sendRequest(ctx, http.MethodGet, "emails/scheduled?"+q.Encode(), nil, &out); err != nil {
+	if err := applyScheduledSort(q, sort); err != nil {
 		return nil, err
 	}
 
-	if out.Success && out.Data != nil {
-		return out.Data, nil
-	}
-
-	if out.Message == "" {
-		out.Message = "Unknown"
-	}
-
-	return nil, fmt.Errorf("the API returned an error: %s", out.Message)
+	return doJSONData[ScheduledEmailsResponse](ctx, c, http.MethodGet, "emails/scheduled?"+q.Encode(), nil)
 
 }
 
-func (c *Client) buildBasePayload(payload BasePayload) (map[string]any, error) {
+func (c *Client) buildBasePayload(ctx context.Context, payload BasePayload) (map[string]any, error) {
+
+	c.applyDefaults(&payload)
 
 	if err := requireSubject(payload.Subject); err != nil {
 		return nil, err
 	}
 
-	if len(payload.To) == 0 {
-		return nil, errors.New("field to is required and must have at least one recipient")
-	}
+	for _, list := range []*[]EmailAddress{&payload.To, &payload.Cc, &payload.Bcc, &payload.ReplyTo} {
 
-	result := map[string]any{
+		expanded, err := c.expandGroups(ctx, *list)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*list = expanded
+
+	}
+
+	if c.normalization != nil {
+		n := newRecipientNormalizer(*c.normalization)
+		n.payload(&payload)
+		n.flush(ctx)
+	}
+
+	if len(payload.To) == 0 {
+		return nil, newFieldError("to", FieldErrorRequired, "field to is required and must have at least one recipient")
+	}
+
+	if err := validateAddresses(payload.From, payload.To, payload.Cc, payload.Bcc, payload.ReplyTo, c.maxRecipients); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkRecipientLimits(payload.To, payload.Cc, payload.Bcc); err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyFromDomain(ctx, payload.From); err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{
 		"subject": payload.Subject,
 	}
 
@@ -518,29 +880,53 @@ func (c *Client) buildBasePayload(payload BasePayload) (map[string]any, error) {
 		result["tags"] = payload.Tags
 
 	}
-	if payload.Headers != nil {
+	headers, err := applyPriority(payload.Headers, payload.Priority)
+
+	if err != nil {
+		return nil, err
+	}
 
-		if err := validateAssociativeMap(payload.Headers, "headers"); err != nil {
+	if headers != nil {
+
+		if err := validateAssociativeMap(headers, "headers"); err != nil {
+			return nil, err
+		}
+
+		if err := validateWellKnownHeaders(headers); err != nil {
 			return nil, err
 		}
 
-		result["headers"] = payload.Headers
+		result["headers"] = headers
 
 	}
+	if err := c.checkAttachmentPolicy(payload.Attachments); err != nil {
+		return nil, err
+	}
+
 	if len(payload.Attachments) > 0 {
 
 		arr := make([]Attachment, 0, len(payload.Attachments))
 
-		for _, att := range payload.Attachments {
+		for i, att := range payload.Attachments {
+
+			att, err := att.Load()
+
+			if err != nil {
+				return nil, withFieldPrefix(fmt.Sprintf("attachments[%d]", i), err)
+			}
 
 			if err := att.validate(); err != nil {
-				return nil, err
+				return nil, withFieldPrefix(fmt.Sprintf("attachments[%d]", i), err)
 			}
 
 			arr = append(arr, att)
 
 		}
 
+		if err := checkInlineFileNames(arr); err != nil {
+			return nil, err
+		}
+
 		result["attachments"] = arr
 
 	}
@@ -549,20 +935,20 @@ func (c *Client) buildBasePayload(payload BasePayload) (map[string]any, error) {
 		result["scheduled_at"] = *payload.ScheduledAt
 	}
 
-	if payload.ReferenceID != nil {
-
-		if err := validateReferenceID(*payload.ReferenceID); err != nil {
-			return nil, err
-		}
-
-		result["reference_id"] = *payload.ReferenceID
+	var refID string
 
+	if payload.ReferenceID != nil {
+		refID = *payload.ReferenceID
 	} else {
+		refID = c.GetReferenceID()
+	}
 
-		result["reference_id"] = c.GetReferenceID()
-
+	if err := validateReferenceID(refID); err != nil {
+		return nil, err
 	}
 
+	result["reference_id"] = refID
+
 	return result, nil
 
 }
@@ -574,7 +960,15 @@ func (c *Client) normalizeBulkMessages(in []BulkMessage) ([]map[string]any, erro
 	for i, m := range in {
 
 		if len(m.To) == 0 {
-			return nil, fmt.Errorf("messages[%d].to must have at least one recipient", i)
+			return nil, newFieldError(fmt.Sprintf("messages[%d].to", i), FieldErrorRequired, "messages[%d].to must have at least one recipient", i)
+		}
+
+		if err := validateAddresses(m.From, m.To, m.Cc, m.Bcc, m.ReplyTo, c.maxRecipients); err != nil {
+			return nil, withFieldPrefix(fmt.Sprintf("messages[%d]", i), err)
+		}
+
+		if err := c.checkRecipientLimits(m.To, m.Cc, m.Bcc); err != nil {
+			return nil, withFieldPrefix(fmt.Sprintf("messages[%d]", i), err)
 		}
 
 		item := map[string]any{
@@ -594,24 +988,28 @@ func (c *Client) normalizeBulkMessages(in []BulkMessage) ([]map[string]any, erro
 			item["reply_to"] = emailAddressesToJSON(m.ReplyTo)
 		}
 
-		if m.ReferenceID != nil {
-
-			if err := validateReferenceID(*m.ReferenceID); err != nil {
-				return nil, fmt.Errorf("messages[%d].reference_id: %w", i, err)
-			}
-
-			item["reference_id"] = *m.ReferenceID
+		var refID string
 
+		if m.ReferenceID != nil {
+			refID = *m.ReferenceID
 		} else {
+			refID = c.GetReferenceID()
+		}
 
-			item["reference_id"] = c.GetReferenceID()
+		if err := validateReferenceID(refID); err != nil {
+			return nil, withFieldPrefix(fmt.Sprintf("messages[%d]", i), err)
+		}
 
+		item["reference_id"] = refID
+
+		if m.ScheduledAt != nil {
+			item["scheduled_at"] = *m.ScheduledAt
 		}
 
 		if m.TemplateData != nil {
 
 			if err := validateTemplateData(m.TemplateData); err != nil {
-				return nil, fmt.Errorf("messages[%d].template_data: %w", i, err)
+				return nil, withFieldPrefix(fmt.Sprintf("messages[%d]", i), err)
 			}
 
 			item["template_data"] = m.TemplateData
@@ -628,57 +1026,355 @@ func (c *Client) normalizeBulkMessages(in []BulkMessage) ([]map[string]any, erro
 
 func (c *Client) sendRequest(ctx context.Context, method, endpoint string, body any, out any) error {
 
-	if !strings.HasPrefix(endpoint, "http") {
-		endpoint = c.apiBaseURL + strings.TrimLeft(endpoint, "/")
+	if c.responseCache != nil {
+		return c.sendCachedRequest(ctx, method, endpoint, body, out)
 	}
 
-	var r io.Reader
+	return c.doRequest(ctx, method, endpoint, body, out)
+
+}
+
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body any, out any) error {
+
+	if isEstimating(ctx) {
+		return fmt.Errorf("%s %s: refusing to send a request while estimating the payload size", method, endpoint)
+	}
+
+	ctx, release, err := c.lifecycle.acquire(ctx)
 
-	if method == http.MethodGet || body == nil {
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	ctx = c.withContextTags(ctx)
+
+	absolute := strings.HasPrefix(endpoint, "http")
+	path := strings.TrimLeft(endpoint, "/")
+
+	var payload []byte
+	var contentEncoding string
+
+	if method != http.MethodGet && body != nil {
+
+		b, err := encodeRequestBody(body)
 
-		r = nil
+		if err != nil {
+			return err
+		}
+
+		c.logRequestStart(ctx, method, endpoint, b)
+
+		if c.compress && len(b) >= c.compressionThreshold {
+
+			gz, err := gzipBytes(b)
+
+			if err != nil {
+				return fmt.Errorf("failed to compress request body: %w", err)
+			}
+
+			b = gz
+			contentEncoding = "gzip"
+
+		}
+
+		payload = b
 
 	} else {
 
-		b, err := json.Marshal(body)
+		c.logRequestStart(ctx, method, endpoint, nil)
+
+	}
+
+	timeout := c.operationTimeout(ctx, operationClassFor(method, endpoint))
+
+	failovers := 0
+	failover := false
+
+	for attempt := 1; ; {
+
+		base := c.apiBaseURL
+		target := endpoint
+
+		if !absolute {
+
+			if c.failover != nil {
+				base = c.failover.pick(ctx, c.http)
+			}
+
+			target = base + path
+
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+
+		// Each attempt decodes into its own value, so fields from a failed
+		// attempt's response cannot leak into the one that is returned.
+		decoded := newDecodeTarget(out)
+
+		start := time.Now()
+		res, err := c.roundTrip(attemptCtx, method, target, payload, contentEncoding, decoded)
+
+		cancel()
+
+		if c.failover != nil && !absolute {
+			c.failover.report(base, res.class, res.status)
+		}
+
+		ev := RequestEvent{
+			Method:     method,
+			Endpoint:   routeLabel(base, target),
+			StatusCode: res.status,
+			Duration:   time.Since(start),
+			Attempt:    attempt,
+			Failover:   failover,
+			ErrorClass: res.class,
+			Tags:       ContextTagsFrom(ctx),
+		}
+
+		c.observeRequest(ev)
+		c.logRequestEnd(ctx, ev, err)
+
+		if err != nil && c.failover != nil && !absolute && isDialError(err) && failovers < c.failover.size()-1 && ctx.Err() == nil {
+			failovers++
+			failover = true
+			continue
+		}
+
+		wait, retry := c.shouldRetry(ctx, &RetryResponse{
+			Method:     method,
+			Endpoint:   ev.Endpoint,
+			Attempt:    attempt,
+			StatusCode: res.status,
+			Header:     res.header,
+			Body:       res.body,
+			ErrorClass: res.class,
+		}, err)
+
+		if !retry {
+
+			if res.decoded {
+				assignDecoded(out, decoded)
+			}
+
+			return err
+
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+
+		attempt++
+		failover = false
+
+	}
+
+}
+
+func encodeRequestBody(body any) ([]byte, error) {
+
+	var b []byte
+
+	switch t := body.(type) {
+
+	case json.RawMessage:
+		b = append([]byte(nil), t...)
+
+	case []byte:
+		b = append([]byte(nil), t...)
+
+	case io.Reader:
+
+		data, err := io.ReadAll(t)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+
+		b = data
+
+	default:
+
+		data, err := json.Marshal(body)
 
 		if err != nil {
-			return fmt.Errorf("failed to encode request body: %w", err)
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
 		}
 
-		r = strings.NewReader(string(b))
+		return data, nil
+
+	}
+
+	if !json.Valid(b) {
+		return nil, errors.New("request body must be valid JSON")
+	}
+
+	return b, nil
+
+}
+
+type roundTripResult struct {
+	status  int
+	class   ErrorClass
+	header  http.Header
+	body    []byte
+	decoded bool
+}
+
+func newDecodeTarget(out any) any {
 
+	v := reflect.ValueOf(out)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return out
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, endpoint, r)
+	return reflect.New(v.Type().Elem()).Interface()
+
+}
+
+func assignDecoded(out, decoded any) {
+
+	v := reflect.ValueOf(out)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return
+	}
+
+	v.Elem().Set(reflect.ValueOf(decoded).Elem())
+
+}
+
+func (c *Client) roundTrip(ctx context.Context, method, endpoint string, payload []byte, contentEncoding string, out any) (roundTripResult, error) {
+
+	var r io.Reader
+
+	if payload != nil {
+		r = bytes.NewReader(payload)
+	}
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, method, endpoint, r)
 
 	if err != nil {
-		return err
+		return roundTripResult{class: ErrorClassNetwork}, err
+	}
+
+	if payload != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+
 	req.Header.Set("Authorization", "Bearer "+c.APIKey)
-	req.Header.Set("User-Agent", defaultUserAgent)
+
+	// An empty value stops net/http from sending its own Go-http-client User-Agent.
+	req.Header.Set("User-Agent", c.userAgentHeader(ctx))
+
+	applyRequestHeaders(ctx, req)
 
 	resp, err := c.http.Do(req)
 
 	if err != nil {
-		return fmt.Errorf("HTTP request failed: %w", err)
+		return roundTripResult{class: classifyTransportError(ctx, err)}, fmt.Errorf("HTTP request failed: %w", err)
 	}
 
 	defer resp.Body.Close()
 
-	raw, err := io.ReadAll(resp.Body)
+	res := roundTripResult{status: resp.StatusCode, header: resp.Header}
+
+	// ResponseHeaderTimeout only covers the wait for headers, so bound the body read separately.
+	var readTimedOut atomic.Bool
+
+	if c.readTimeout > 0 {
+
+		timer := time.AfterFunc(c.readTimeout, func() {
+			readTimedOut.Store(true)
+			cancel()
+		})
+
+		defer timer.Stop()
+
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, c.maxResponseSize+1))
 
 	if err != nil {
-		return fmt.Errorf("failed to read API response: %w", err)
+
+		if readTimedOut.Load() {
+			res.class = ErrorClassTimeout
+			return res, fmt.Errorf("failed to read API response: body not received within the read timeout of %s", c.readTimeout)
+		}
+
+		res.class = classifyTransportError(ctx, err)
+		return res, fmt.Errorf("failed to read API response: %w", err)
+
+	}
+
+	if int64(len(raw)) > c.maxResponseSize {
+		res.class = ErrorClassDecode
+		return res, fmt.Errorf("the API response exceeds the maximum size of %d bytes", c.maxResponseSize)
+	}
+
+	res.body = raw
+
+	captureRawResponse(ctx, raw)
+	captureResponseMeta(ctx, resp)
+
+	if resp.StatusCode == http.StatusNotModified {
+		return res, nil
 	}
 
 	if err := json.Unmarshal(raw, out); err != nil {
-		return fmt.Errorf("the API response is not valid JSON: %v", err)
+		res.class = ErrorClassDecode
+		return res, fmt.Errorf("the API response is not valid JSON: %v", err)
 	}
 
-	return nil
+	res.decoded = true
+
+	var envelope struct {
+		Success *bool `json:"success"`
+	}
+
+	if json.Unmarshal(raw, &envelope) == nil && envelope.Success != nil && !*envelope.Success {
+		res.class = ErrorClassAPI
+		return res, nil
+	}
+
+	if resp.StatusCode >= 400 {
+		res.class = ErrorClassHTTP
+		return res, nil
+	}
+
+	return res, nil
+
+}
+
+func gzipBytes(b []byte) ([]byte, error) {
+
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
 
 }
 
@@ -687,11 +1383,27 @@ var refIDRe = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
 func validateReferenceID(s string) error {
 
 	if s != strings.TrimSpace(s) {
-		return errors.New("reference_id must not contain whitespace")
+		return newFieldError("reference_id", FieldErrorInvalid, "reference_id must not contain whitespace")
 	}
 
 	if !refIDRe.MatchString(s) {
-		return fmt.Errorf("reference_id must be a %d-character hexadecimal string", ReferenceIDLength)
+		return newFieldError("reference_id", FieldErrorInvalid, "reference_id must be a %d-character hexadecimal string", ReferenceIDLength)
+	}
+
+	return nil
+
+}
+
+func validateAMPHTML(amp string, html *string) error {
+
+	if html == nil || strings.TrimSpace(*html) == "" {
+		return newFieldError("html", FieldErrorRequired, "amp_html requires an html fallback body")
+	}
+
+	lower := strings.ToLower(amp)
+
+	if !strings.Contains(lower, "⚡4email") && !strings.Contains(lower, "amp4email") {
+		return newFieldError("amp_html", FieldErrorInvalid, "amp_html must be an AMP for Email document (<html ⚡4email> or <html amp4email>)")
 	}
 
 	return nil
@@ -701,11 +1413,15 @@ func validateReferenceID(s string) error {
 func requireSubject(s string) error {
 
 	if strings.TrimSpace(s) == "" {
-		return fmt.Errorf("subject must be a non-empty string with a maximum length of %d characters", MaxSubjectLength)
+		return newFieldError("subject", FieldErrorRequired, "subject must be a non-empty string with a maximum length of %d characters", MaxSubjectLength)
 	}
 
 	if runeLen(s) > MaxSubjectLength {
-		return fmt.Errorf("subject must be a non-empty string with a maximum length of %d characters", MaxSubjectLength)
+		return newFieldError("subject", FieldErrorTooLong, "subject must be a non-empty string with a maximum length of %d characters", MaxSubjectLength)
+	}
+
+	if containsControl(s) {
+		return newInjectionError("subject", "subject")
 	}
 
 	return nil
@@ -717,19 +1433,27 @@ func validateAssociativeMap(m AssocMap, label string) error {
 	for k, v := range m {
 
 		if strings.TrimSpace(k) == "" {
-			return fmt.Errorf("%s keys must be non-empty strings", label)
+			return newFieldError(label, FieldErrorInvalid, "%s keys must be non-empty strings", label)
+		}
+
+		if containsControl(k) {
+			return newInjectionError(label, label+" keys")
 		}
 
 		if runeLen(k) > MaxAssociativeMapKeyLength {
-			return fmt.Errorf("%s key must not exceed %d characters", label, MaxAssociativeMapKeyLength)
+			return newFieldError(label, FieldErrorTooLong, "%s key must not exceed %d characters", label, MaxAssociativeMapKeyLength)
 		}
 
 		if !isAcceptableAssocValue(v) {
-			return fmt.Errorf("%s must be an associative map with string keys and values (string/number/bool)", label)
+			return newFieldError(label+"."+k, FieldErrorInvalid, "%s must be an associative map with string keys and values (string/number/bool)", label)
+		}
+
+		if str, ok := v.(string); ok && containsControl(str) {
+			return newInjectionError(label+"."+k, label+" values")
 		}
 
 		if valLen(v) > MaxAssociativeMapValueLength {
-			return fmt.Errorf("%s value must not exceed %d characters", label, MaxAssociativeMapValueLength)
+			return newFieldError(label+"."+k, FieldErrorTooLong, "%s value must not exceed %d characters", label, MaxAssociativeMapValueLength)
 		}
 
 	}
@@ -743,7 +1467,51 @@ func validateTemplateData(m map[string]any) error {
 	for k := range m {
 
 		if strings.TrimSpace(k) == "" {
-			return errors.New("template_data keys must be strings and non-empty")
+			return newFieldError("template_data", FieldErrorInvalid, "template_data keys must be strings and non-empty")
+		}
+
+		if err := validateTemplateValue(k, m[k], 1); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
+
+func validateTemplateValue(path string, v any, depth int) error {
+
+	if depth > MaxTemplateDataDepth {
+		return newFieldError("template_data."+path, FieldErrorInvalid, "template_data.%s is nested more than %d levels deep", path, MaxTemplateDataDepth)
+	}
+
+	switch t := v.(type) {
+
+	case json.Number:
+
+		if !isJSONNumber(t) {
+			return newFieldError("template_data."+path, FieldErrorInvalid, "template_data.%s is not a valid number: %q", path, string(t))
+		}
+
+	case map[string]any:
+
+		for k, e := range t {
+
+			if err := validateTemplateValue(path+"."+k, e, depth+1); err != nil {
+				return err
+			}
+
+		}
+
+	case []any:
+
+		for i, e := range t {
+
+			if err := validateTemplateValue(fmt.Sprintf("%s[%d]", path, i), e, depth+1); err != nil {
+				return err
+			}
+
 		}
 
 	}
@@ -754,11 +1522,14 @@ func validateTemplateData(m map[string]any) error {
 
 func isAcceptableAssocValue(v any) bool {
 
-	switch v.(type) {
+	switch t := v.(type) {
 
-	case string, bool, int, int32, int64, uint, uint32, uint64, float32, float64:
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 		return true
 
+	case json.Number:
+		return isJSONNumber(t)
+
 	default:
 		return false
 
@@ -766,6 +1537,33 @@ func isAcceptableAssocValue(v any) bool {
 
 }
 
+func isJSONNumber(n json.Number) bool {
+
+	s := string(n)
+
+	if s == "" || !(s[0] == '-' || (s[0] >= '0' && s[0] <= '9')) {
+		return false
+	}
+
+	return json.Valid([]byte(s))
+
+}
+
+func DecodeTemplateData(r io.Reader) (map[string]any, error) {
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var data map[string]any
+
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode template data: %w", err)
+	}
+
+	return data, nil
+
+}
+
 func valLen(v any) int {
 
 	switch t := v.(type) {
@@ -781,7 +1579,10 @@ func valLen(v any) int {
 
 		return 5
 
-	case int, int32, int64, uint, uint32, uint64, float32, float64:
+	case json.Number:
+		return len(t)
+
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
 		return len(fmt.Sprintf("%v", t))
 
 	default:
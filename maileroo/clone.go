@@ -0,0 +1,65 @@
+package maileroo
+
+import (
+	"net/http"
+)
+
+func (c *Client) With(opts ...ClientOption) (*Client, error) {
+
+	clone := *c
+
+	clone.lifecycle = c.lifecycle.child()
+	clone.observers = append([]Observer(nil), c.observers...)
+	clone.contextTaggers = append([]ContextTagger(nil), c.contextTaggers...)
+	clone.appInfo = append([]string(nil), c.appInfo...)
+	clone.failoverURLs = append([]string(nil), c.failoverURLs...)
+	clone.defaultReplyTo = append([]EmailAddress(nil), c.defaultReplyTo...)
+	clone.roundTrippers = append([]RoundTripperMiddleware(nil), c.roundTrippers...)
+
+	for _, opt := range opts {
+		if err := opt(&clone); err != nil {
+			return nil, err
+		}
+	}
+
+	if clone.connectTimeout != c.connectTimeout || clone.readTimeout != c.readTimeout || len(clone.roundTrippers) != len(c.roundTrippers) {
+		clone.http = &http.Client{}
+		clone.http.Transport = clone.buildTransport()
+	}
+
+	if clone.apiBaseURL != c.apiBaseURL || !equalStrings(clone.failoverURLs, c.failoverURLs) ||
+		clone.failoverThreshold != c.failoverThreshold || clone.failoverCooldown != c.failoverCooldown {
+
+		clone.failover = nil
+
+		if len(clone.failoverURLs) > 0 {
+			clone.failover = newFailoverPool(clone.apiBaseURL, clone.failoverURLs, clone.failoverThreshold, clone.failoverCooldown)
+		}
+
+	}
+
+	if clone.APIKey != c.APIKey && clone.domainVerifier != nil && clone.domainVerifier == c.domainVerifier {
+		clone.domainVerifier = &domainVerifier{ttl: c.domainVerifier.ttl, entries: map[string]domainCacheEntry{}}
+	}
+
+	return &clone, nil
+
+}
+
+func equalStrings(a, b []string) bool {
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+
+		if a[i] != b[i] {
+			return false
+		}
+
+	}
+
+	return true
+
+}
@@ -0,0 +1,167 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+type ComplaintAction int
+
+const (
+	ComplaintActionSkip ComplaintAction = iota
+	ComplaintActionError
+)
+
+type ComplaintPolicy struct {
+	Checker SuppressionChecker
+	Action  ComplaintAction
+}
+
+type SkippedRecipient struct {
+	Field   string
+	Address string
+	Reason  string
+}
+
+type ComplaintError struct {
+	Recipients []SkippedRecipient
+}
+
+func (e *ComplaintError) Error() string {
+
+	addrs := make([]string, 0, len(e.Recipients))
+
+	for _, r := range e.Recipients {
+		addrs = append(addrs, r.Address)
+	}
+
+	return "recipients have complained about earlier emails: " + strings.Join(addrs, ", ")
+
+}
+
+type MemoryComplaintStore struct {
+	mu    sync.RWMutex
+	addrs map[string]bool
+}
+
+func NewMemoryComplaintStore() *MemoryComplaintStore {
+	return &MemoryComplaintStore{addrs: map[string]bool{}}
+}
+
+func (s *MemoryComplaintStore) Suppress(ctx context.Context, address, reason string) error {
+
+	if reason != SuppressionReasonComplaint {
+		return nil
+	}
+
+	s.mu.Lock()
+	s.addrs[strings.ToLower(strings.TrimSpace(address))] = true
+	s.mu.Unlock()
+
+	return nil
+
+}
+
+func (s *MemoryComplaintStore) Remove(address string) {
+
+	s.mu.Lock()
+	delete(s.addrs, strings.ToLower(strings.TrimSpace(address)))
+	s.mu.Unlock()
+
+}
+
+func (s *MemoryComplaintStore) IsSuppressed(ctx context.Context, address string) (bool, error) {
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.addrs[strings.ToLower(strings.TrimSpace(address))], nil
+
+}
+
+func WithComplaintPolicy(policy ComplaintPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.Checker == nil {
+			return errors.New("complaint policy requires a checker")
+		}
+		if policy.Action < ComplaintActionSkip || policy.Action > ComplaintActionError {
+			return errors.New("unknown complaint action")
+		}
+		c.complaintPolicy = &policy
+		return nil
+	}
+}
+
+func (c *Client) applyComplaintPolicy(ctx context.Context, payload *BasePayload) ([]SkippedRecipient, error) {
+
+	if c.complaintPolicy == nil || isEstimating(ctx) {
+		return nil, nil
+	}
+
+	var skipped []SkippedRecipient
+
+	lists := []struct {
+		field string
+		addrs *[]EmailAddress
+	}{
+		{"to", &payload.To},
+		{"cc", &payload.Cc},
+		{"bcc", &payload.Bcc},
+	}
+
+	for _, l := range lists {
+
+		expanded, err := c.expandGroups(ctx, *l.addrs)
+
+		if err != nil {
+			return nil, err
+		}
+
+		kept := make([]EmailAddress, 0, len(expanded))
+
+		for _, a := range expanded {
+
+			complained, err := c.complaintPolicy.Checker.IsSuppressed(ctx, a.Address)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to check complaint status of %q: %w", a.Address, err)
+			}
+
+			if complained {
+				skipped = append(skipped, SkippedRecipient{Field: l.field, Address: a.Address, Reason: SuppressionReasonComplaint})
+				continue
+			}
+
+			kept = append(kept, a)
+
+		}
+
+		*l.addrs = kept
+
+	}
+
+	if len(skipped) == 0 {
+		return nil, nil
+	}
+
+	if c.complaintPolicy.Action == ComplaintActionError || len(payload.To) == 0 {
+		return nil, &ComplaintError{Recipients: skipped}
+	}
+
+	logger := c.logger
+
+	if logger == nil {
+		return skipped, nil
+	}
+
+	for _, s := range skipped {
+		logger.InfoContext(ctx, "maileroo: skipped recipient who complained", slog.String("field", s.Field), slog.String("address", c.redactAddress(s.Address)))
+	}
+
+	return skipped, nil
+
+}
@@ -0,0 +1,269 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type ContentLintMode int
+
+const (
+	ContentLintOff ContentLintMode = iota
+	ContentLintWarn
+	ContentLintStrict
+)
+
+const (
+	LintBrokenMergeTag     = "broken_merge_tag"
+	LintMissingLink        = "missing_link"
+	LintMissingUnsubscribe = "missing_unsubscribe"
+	LintBodyMismatch       = "body_mismatch"
+)
+
+const minPlainToHTMLRatio = 0.2
+
+type ContentIssue struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+type ContentLintError struct {
+	Issues []ContentIssue
+}
+
+func (e *ContentLintError) Error() string {
+
+	msgs := make([]string, 0, len(e.Issues))
+
+	for _, issue := range e.Issues {
+		msgs = append(msgs, issue.Message)
+	}
+
+	return "content lint failed: " + strings.Join(msgs, "; ")
+
+}
+
+func WithContentLint(mode ContentLintMode) ClientOption {
+	return func(c *Client) error {
+		if mode < ContentLintOff || mode > ContentLintStrict {
+			return errors.New("unknown content lint mode")
+		}
+		c.contentLint = mode
+		return nil
+	}
+}
+
+func LintContent(subject string, htmlBody, plainBody *string) []ContentIssue {
+
+	var issues []ContentIssue
+
+	issues = append(issues, lintMergeTags("subject", subject)...)
+
+	if htmlBody != nil {
+		issues = append(issues, lintMergeTags("html", *htmlBody)...)
+	}
+
+	if plainBody != nil {
+		issues = append(issues, lintMergeTags("plain", *plainBody)...)
+	}
+
+	if htmlBody == nil || plainBody == nil {
+		return issues
+	}
+
+	text, links := htmlTextAndLinks(*htmlBody)
+	plain := *plainBody
+
+	for _, link := range links {
+
+		if !strings.Contains(plain, link) {
+			issues = append(issues, ContentIssue{
+				Field:   "plain",
+				Code:    LintMissingLink,
+				Message: fmt.Sprintf("plain body is missing link %s from the html body", link),
+			})
+		}
+
+	}
+
+	htmlUnsub := strings.Contains(strings.ToLower(text), "unsubscribe")
+	plainUnsub := strings.Contains(strings.ToLower(plain), "unsubscribe")
+
+	if htmlUnsub && !plainUnsub {
+		issues = append(issues, ContentIssue{Field: "plain", Code: LintMissingUnsubscribe, Message: "html body mentions unsubscribing but the plain body does not"})
+	}
+
+	if plainUnsub && !htmlUnsub {
+		issues = append(issues, ContentIssue{Field: "html", Code: LintMissingUnsubscribe, Message: "plain body mentions unsubscribing but the html body does not"})
+	}
+
+	htmlLen := utf8.RuneCountInString(strings.Join(strings.Fields(text), " "))
+	plainLen := utf8.RuneCountInString(strings.Join(strings.Fields(plain), " "))
+
+	if htmlLen >= 200 && float64(plainLen) < float64(htmlLen)*minPlainToHTMLRatio {
+		issues = append(issues, ContentIssue{
+			Field:   "plain",
+			Code:    LintBodyMismatch,
+			Message: fmt.Sprintf("plain body has %d characters of text against %d in the html body", plainLen, htmlLen),
+		})
+	}
+
+	return issues
+
+}
+
+func lintMergeTags(field, s string) []ContentIssue {
+
+	var issues []ContentIssue
+
+	rest := s
+
+	for {
+
+		open := strings.Index(rest, "{{")
+		closing := strings.Index(rest, "}}")
+
+		if open < 0 && closing < 0 {
+			break
+		}
+
+		if open < 0 || (closing >= 0 && closing < open) {
+			issues = append(issues, ContentIssue{Field: field, Code: LintBrokenMergeTag, Message: fmt.Sprintf("%s has a closing }} without a matching {{", field)})
+			rest = rest[closing+2:]
+			continue
+		}
+
+		next := strings.Index(rest[open+2:], "{{")
+		end := strings.Index(rest[open+2:], "}}")
+
+		if end < 0 || (next >= 0 && next < end) {
+			issues = append(issues, ContentIssue{Field: field, Code: LintBrokenMergeTag, Message: fmt.Sprintf("%s has an unclosed merge tag near %q", field, snippet(rest[open:]))})
+
+			if end < 0 {
+				break
+			}
+
+			rest = rest[open+2+next:]
+			continue
+		}
+
+		rest = rest[open+2+end+2:]
+
+	}
+
+	return issues
+
+}
+
+func snippet(s string) string {
+
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		s = s[:i]
+	}
+
+	if runes := []rune(s); len(runes) > 24 {
+		return string(runes[:24]) + "…"
+	}
+
+	return s
+
+}
+
+func htmlTextAndLinks(s string) (string, []string) {
+
+	z := html.NewTokenizer(strings.NewReader(s))
+
+	var text strings.Builder
+	var links []string
+	seen := map[string]bool{}
+	skip := 0
+
+	for {
+
+		switch z.Next() {
+
+		case html.ErrorToken:
+			return text.String(), links
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+
+			tok := z.Token()
+
+			if tok.DataAtom == atom.Script || tok.DataAtom == atom.Style {
+				skip++
+				continue
+			}
+
+			if tok.DataAtom != atom.A {
+				continue
+			}
+
+			for _, attr := range tok.Attr {
+
+				href := strings.TrimSpace(attr.Val)
+
+				if attr.Key != "href" || !(strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://")) || seen[href] {
+					continue
+				}
+
+				seen[href] = true
+				links = append(links, href)
+
+			}
+
+		case html.EndTagToken:
+
+			if tok := z.Token(); (tok.DataAtom == atom.Script || tok.DataAtom == atom.Style) && skip > 0 {
+				skip--
+			}
+
+		case html.TextToken:
+
+			if skip == 0 {
+				text.Write(z.Text())
+				text.WriteByte(' ')
+			}
+
+		}
+
+	}
+
+}
+
+func (c *Client) lintContent(ctx context.Context, subject string, htmlBody, plainBody *string) error {
+
+	if c.contentLint == ContentLintOff {
+		return nil
+	}
+
+	issues := LintContent(subject, htmlBody, plainBody)
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	if c.contentLint == ContentLintStrict {
+		return &ContentLintError{Issues: issues}
+	}
+
+	logger := c.logger
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	for _, issue := range issues {
+		logger.WarnContext(ctx, "maileroo: content lint", slog.String("field", issue.Field), slog.String("code", issue.Code), slog.String("issue", issue.Message))
+	}
+
+	return nil
+
+}
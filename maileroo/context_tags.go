@@ -0,0 +1,133 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+type ContextTagger func(ctx context.Context) map[string]string
+
+type contextTagsKey struct{}
+
+func WithContextTagger(tagger ContextTagger) ClientOption {
+	return func(c *Client) error {
+		if tagger == nil {
+			return errors.New("context tagger must not be nil")
+		}
+		c.contextTaggers = append(c.contextTaggers, tagger)
+		return nil
+	}
+}
+
+func ContextTagsFrom(ctx context.Context) map[string]string {
+
+	tags, _ := ctx.Value(contextTagsKey{}).(map[string]string)
+
+	return tags
+
+}
+
+func (c *Client) withContextTags(ctx context.Context) context.Context {
+
+	if len(c.contextTaggers) == 0 {
+		return ctx
+	}
+
+	var tags map[string]string
+
+	for _, tagger := range c.contextTaggers {
+
+		for k, v := range tagger(ctx) {
+
+			k = strings.TrimSpace(k)
+
+			if k == "" || v == "" {
+				continue
+			}
+
+			if tags == nil {
+				tags = map[string]string{}
+			}
+
+			tags[k] = v
+
+		}
+
+	}
+
+	if tags == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextTagsKey{}, tags)
+
+}
+
+func sortedTagKeys(tags map[string]string) []string {
+
+	keys := make([]string, 0, len(tags))
+
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+
+}
+
+func contextTagAttrs(tags map[string]string) []any {
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	attrs := make([]any, 0, len(tags))
+
+	for _, k := range sortedTagKeys(tags) {
+		attrs = append(attrs, slog.String(k, tags[k]))
+	}
+
+	return []any{slog.Group("context", attrs...)}
+
+}
+
+func userAgentComment(tags map[string]string) string {
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(tags))
+
+	for _, k := range sortedTagKeys(tags) {
+		parts = append(parts, userAgentCommentText(k)+"="+userAgentCommentText(tags[k]))
+	}
+
+	return "(" + strings.Join(parts, "; ") + ")"
+
+}
+
+func userAgentCommentText(s string) string {
+
+	return strings.Map(func(r rune) rune {
+
+		switch {
+
+		case r < ' ' || r == 0x7f || r > 0x7e:
+			return '_'
+
+		case strings.ContainsRune(`()\;=`, r):
+			return '_'
+
+		}
+
+		return r
+
+	}, s)
+
+}
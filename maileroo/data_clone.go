@@ -0,0 +1,217 @@
+package maileroo
+
+func (d BasicEmailData) Clone() BasicEmailData {
+
+	out := d
+
+	out.From = d.From.clone()
+	out.To = cloneAddresses(d.To)
+	out.Cc = cloneAddresses(d.Cc)
+	out.Bcc = cloneAddresses(d.Bcc)
+	out.ReplyTo = cloneAddresses(d.ReplyTo)
+	out.HTML = clonePtr(d.HTML)
+	out.Plain = clonePtr(d.Plain)
+	out.AMPHTML = clonePtr(d.AMPHTML)
+	out.Tracking = clonePtr(d.Tracking)
+	out.Tags = cloneValueMap(d.Tags)
+	out.Headers = cloneValueMap(d.Headers)
+	out.Attachments = cloneSlice(d.Attachments)
+	out.ScheduledAt = clonePtr(d.ScheduledAt)
+	out.ReferenceID = clonePtr(d.ReferenceID)
+	out.PlaceholderData = cloneValueMap(d.PlaceholderData)
+	out.TrackingSettings = d.TrackingSettings.clone()
+
+	return out
+
+}
+
+func (d TemplatedEmailData) Clone() TemplatedEmailData {
+
+	out := d
+
+	out.From = d.From.clone()
+	out.To = cloneAddresses(d.To)
+	out.Cc = cloneAddresses(d.Cc)
+	out.Bcc = cloneAddresses(d.Bcc)
+	out.ReplyTo = cloneAddresses(d.ReplyTo)
+	out.TemplateData = cloneValueMap(d.TemplateData)
+	out.Tracking = clonePtr(d.Tracking)
+	out.Tags = cloneValueMap(d.Tags)
+	out.Headers = cloneValueMap(d.Headers)
+	out.Attachments = cloneSlice(d.Attachments)
+	out.ScheduledAt = clonePtr(d.ScheduledAt)
+	out.ReferenceID = clonePtr(d.ReferenceID)
+	out.TrackingSettings = d.TrackingSettings.clone()
+
+	return out
+
+}
+
+func (m BulkMessage) Clone() BulkMessage {
+
+	out := m
+
+	out.From = m.From.clone()
+	out.To = cloneAddresses(m.To)
+	out.Cc = cloneAddresses(m.Cc)
+	out.Bcc = cloneAddresses(m.Bcc)
+	out.ReplyTo = cloneAddresses(m.ReplyTo)
+	out.ReferenceID = clonePtr(m.ReferenceID)
+	out.TemplateID = clonePtr(m.TemplateID)
+	out.TemplateData = cloneValueMap(m.TemplateData)
+	out.ScheduledAt = clonePtr(m.ScheduledAt)
+
+	return out
+
+}
+
+func (d BulkEmailData) Clone() BulkEmailData {
+
+	out := d
+
+	out.HTML = clonePtr(d.HTML)
+	out.Plain = clonePtr(d.Plain)
+	out.TemplateID = clonePtr(d.TemplateID)
+	out.Tracking = clonePtr(d.Tracking)
+	out.Tags = cloneValueMap(d.Tags)
+	out.Headers = cloneValueMap(d.Headers)
+	out.Attachments = cloneSlice(d.Attachments)
+	out.TrackingSettings = d.TrackingSettings.clone()
+
+	if d.Messages != nil {
+
+		out.Messages = make([]BulkMessage, len(d.Messages))
+
+		for i, m := range d.Messages {
+			out.Messages[i] = m.Clone()
+		}
+
+	}
+
+	return out
+
+}
+
+func (b *HeaderBuilder) Clone() *HeaderBuilder {
+
+	return &HeaderBuilder{headers: cloneValueMap(b.headers), err: b.err}
+
+}
+
+func (b *TagBuilder) Clone() *TagBuilder {
+
+	return &TagBuilder{tags: cloneValueMap(b.tags), err: b.err, warnings: cloneSlice(b.warnings)}
+
+}
+
+func (t *TrackingSettings) clone() *TrackingSettings {
+
+	if t == nil {
+		return nil
+	}
+
+	out := *t
+	out.Opens = clonePtr(t.Opens)
+	out.Clicks = clonePtr(t.Clicks)
+
+	return &out
+
+}
+
+func (e EmailAddress) clone() EmailAddress {
+
+	e.DisplayName = clonePtr(e.DisplayName)
+
+	return e
+
+}
+
+func cloneAddresses(addrs []EmailAddress) []EmailAddress {
+
+	if addrs == nil {
+		return nil
+	}
+
+	out := make([]EmailAddress, len(addrs))
+
+	for i, a := range addrs {
+		out[i] = a.clone()
+	}
+
+	return out
+
+}
+
+func clonePtr[T any](p *T) *T {
+
+	if p == nil {
+		return nil
+	}
+
+	v := *p
+
+	return &v
+
+}
+
+func cloneSlice[T any](s []T) []T {
+
+	if s == nil {
+		return nil
+	}
+
+	return append(make([]T, 0, len(s)), s...)
+
+}
+
+func cloneValueMap(m map[string]any) map[string]any {
+
+	if m == nil {
+		return nil
+	}
+
+	out := make(map[string]any, len(m))
+
+	for k, v := range m {
+		out[k] = cloneValue(v)
+	}
+
+	return out
+
+}
+
+func cloneValue(v any) any {
+
+	switch v := v.(type) {
+
+	case map[string]any:
+		return cloneValueMap(v)
+
+	case []any:
+
+		out := make([]any, len(v))
+
+		for i, item := range v {
+			out[i] = cloneValue(item)
+		}
+
+		return out
+
+	case []string:
+		return cloneSlice(v)
+
+	case []map[string]any:
+
+		out := make([]map[string]any, len(v))
+
+		for i, item := range v {
+			out[i] = cloneValueMap(item)
+		}
+
+		return out
+
+	}
+
+	return v
+
+}
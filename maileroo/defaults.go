@@ -0,0 +1,176 @@
+package maileroo
+
+import (
+	"errors"
+	"strings"
+)
+
+func WithDefaultFrom(from EmailAddress) ClientOption {
+	return func(c *Client) error {
+		if err := from.validate("default from"); err != nil {
+			return err
+		}
+		c.defaultFrom = &from
+		return nil
+	}
+}
+
+func WithDefaultTags(tags AssocMap) ClientOption {
+	return func(c *Client) error {
+		if err := validateAssociativeMap(tags, "default tags"); err != nil {
+			return err
+		}
+		c.defaultTags = mergeAssocMaps(nil, tags)
+		return nil
+	}
+}
+
+func WithDefaultReplyTo(replyTo ...EmailAddress) ClientOption {
+	return func(c *Client) error {
+		if err := validateAddressList(replyTo, "default reply_to"); err != nil {
+			return err
+		}
+		c.defaultReplyTo = append([]EmailAddress(nil), replyTo...)
+		return nil
+	}
+}
+
+func WithDefaultTracking(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.defaultTracking = &enabled
+		return nil
+	}
+}
+
+func WithDefaultHeaders(headers AssocMap) ClientOption {
+	return func(c *Client) error {
+		if err := validateAssociativeMap(headers, "default headers"); err != nil {
+			return err
+		}
+		c.defaultHeaders = mergeAssocMaps(nil, headers)
+		return nil
+	}
+}
+
+func (c *Client) applyDefaults(payload *BasePayload) {
+
+	if c.defaultFrom != nil && strings.TrimSpace(payload.From.Address) == "" {
+		payload.From = *c.defaultFrom
+	}
+
+	if len(c.defaultReplyTo) > 0 && len(payload.ReplyTo) == 0 {
+		payload.ReplyTo = c.defaultReplyTo
+	}
+
+	if c.defaultTracking != nil && payload.Tracking == nil {
+		payload.Tracking = c.defaultTracking
+	}
+
+	if len(c.defaultTags) > 0 {
+		payload.Tags = mergeAssocMaps(c.defaultTags, payload.Tags)
+	}
+
+	if len(c.defaultHeaders) > 0 {
+		payload.Headers = mergeHeaders(c.defaultHeaders, payload.Headers)
+	}
+
+}
+
+func (c *Client) applyBulkDefaults(data *BulkEmailData) {
+
+	if c.defaultFrom != nil || len(c.defaultReplyTo) > 0 {
+
+		messages := make([]BulkMessage, len(data.Messages))
+
+		for i, m := range data.Messages {
+
+			if c.defaultFrom != nil && strings.TrimSpace(m.From.Address) == "" {
+				m.From = *c.defaultFrom
+			}
+
+			if len(m.ReplyTo) == 0 {
+				m.ReplyTo = c.defaultReplyTo
+			}
+
+			messages[i] = m
+
+		}
+
+		data.Messages = messages
+
+	}
+
+	if c.defaultTracking != nil && data.Tracking == nil {
+		data.Tracking = c.defaultTracking
+	}
+
+	if len(c.defaultTags) > 0 {
+		data.Tags = mergeAssocMaps(c.defaultTags, data.Tags)
+	}
+
+	if len(c.defaultHeaders) > 0 {
+		data.Headers = mergeHeaders(c.defaultHeaders, data.Headers)
+	}
+
+}
+
+func mergeAssocMaps(base, override AssocMap) AssocMap {
+
+	if len(base) == 0 && override == nil {
+		return nil
+	}
+
+	out := make(AssocMap, len(base)+len(override))
+
+	for k, v := range base {
+		out[k] = v
+	}
+
+	for k, v := range override {
+		out[k] = v
+	}
+
+	return out
+
+}
+
+func mergeHeaders(defaults, headers AssocMap) AssocMap {
+
+	out := make(AssocMap, len(defaults)+len(headers))
+
+	for k, v := range defaults {
+
+		overridden := false
+
+		for hk := range headers {
+
+			if strings.EqualFold(hk, k) {
+				overridden = true
+				break
+			}
+
+		}
+
+		if !overridden {
+			out[k] = v
+		}
+
+	}
+
+	for k, v := range headers {
+		out[k] = v
+	}
+
+	return out
+
+}
+
+func WithAPIKey(apiKey string) ClientOption {
+	return func(c *Client) error {
+		if strings.TrimSpace(apiKey) == "" {
+			return errors.New("API key must be a non-empty string")
+		}
+		c.APIKey = apiKey
+		return nil
+	}
+}
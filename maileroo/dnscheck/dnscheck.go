@@ -0,0 +1,331 @@
+package dnscheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/maileroo/maileroo-go-sdk/maileroo"
+)
+
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+type RecordResult struct {
+	Purpose  string
+	Type     string
+	Name     string
+	Expected string
+	Found    []string
+	OK       bool
+	Problem  string
+}
+
+type Report struct {
+	Domain   string
+	Verified bool
+	Records  []RecordResult
+}
+
+func (r *Report) OK() bool {
+
+	for _, rec := range r.Records {
+
+		if !rec.OK {
+			return false
+		}
+
+	}
+
+	return true
+
+}
+
+func (r *Report) Problems() []RecordResult {
+
+	var out []RecordResult
+
+	for _, rec := range r.Records {
+
+		if !rec.OK {
+			out = append(out, rec)
+		}
+
+	}
+
+	return out
+
+}
+
+type Checker struct {
+	client   *maileroo.Client
+	resolver Resolver
+}
+
+func NewChecker(client *maileroo.Client, resolver Resolver) (*Checker, error) {
+
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	return &Checker{client: client, resolver: resolver}, nil
+
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*Report, error) {
+
+	d, err := c.client.GetDomain(ctx, domain)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch required DNS records: %w", err)
+	}
+
+	return CheckRecords(ctx, c.resolver, d), nil
+
+}
+
+func CheckRecords(ctx context.Context, resolver Resolver, d *maileroo.Domain) *Report {
+
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	report := &Report{Domain: d.Name, Verified: d.Verified}
+
+	for _, rec := range d.DNSRecords {
+		report.Records = append(report.Records, checkRecord(ctx, resolver, d.Name, rec))
+	}
+
+	return report
+
+}
+
+func checkRecord(ctx context.Context, resolver Resolver, domain string, rec maileroo.DNSRecord) RecordResult {
+
+	name := fqdn(rec.Name, domain)
+
+	res := RecordResult{
+		Purpose:  rec.Purpose,
+		Type:     strings.ToUpper(rec.Type),
+		Name:     name,
+		Expected: rec.Value,
+	}
+
+	switch res.Type {
+
+	case "TXT":
+		checkTXT(ctx, resolver, &res)
+
+	case "MX":
+		checkMX(ctx, resolver, &res)
+
+	case "CNAME":
+		checkCNAME(ctx, resolver, &res)
+
+	default:
+		res.Problem = fmt.Sprintf("unsupported record type %q", rec.Type)
+
+	}
+
+	return res
+
+}
+
+func checkTXT(ctx context.Context, resolver Resolver, res *RecordResult) {
+
+	txts, err := resolver.LookupTXT(ctx, res.Name)
+
+	if err != nil {
+		res.Problem = lookupProblem(err)
+		return
+	}
+
+	res.Found = txts
+
+	switch res.Purpose {
+
+	case maileroo.DNSRecordSPF:
+		checkSPF(res)
+
+	case maileroo.DNSRecordDMARC:
+		checkDMARC(res)
+
+	default:
+
+		for _, t := range txts {
+
+			if normalizeTXT(t) == normalizeTXT(res.Expected) {
+				res.OK = true
+				return
+			}
+
+		}
+
+		res.Problem = "expected TXT value not found"
+
+	}
+
+}
+
+func checkSPF(res *RecordResult) {
+
+	var spf []string
+
+	for _, t := range res.Found {
+
+		if strings.HasPrefix(strings.ToLower(t), "v=spf1") {
+			spf = append(spf, t)
+		}
+
+	}
+
+	if len(spf) == 0 {
+		res.Problem = "no SPF record published"
+		return
+	}
+
+	if len(spf) > 1 {
+		res.Problem = "multiple SPF records published; receivers treat this as a permanent error"
+		return
+	}
+
+	for _, mech := range strings.Fields(res.Expected) {
+
+		lower := strings.ToLower(mech)
+
+		if lower == "v=spf1" || strings.HasSuffix(lower, "all") {
+			continue
+		}
+
+		if !containsField(spf[0], mech) {
+			res.Problem = fmt.Sprintf("SPF record does not contain %q", mech)
+			return
+		}
+
+	}
+
+	res.OK = true
+
+}
+
+func checkDMARC(res *RecordResult) {
+
+	for _, t := range res.Found {
+
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(t)), "v=dmarc1") {
+			res.OK = true
+			return
+		}
+
+	}
+
+	res.Problem = "no DMARC record published"
+
+}
+
+func checkMX(ctx context.Context, resolver Resolver, res *RecordResult) {
+
+	mxs, err := resolver.LookupMX(ctx, res.Name)
+
+	if err != nil {
+		res.Problem = lookupProblem(err)
+		return
+	}
+
+	want := canonicalHost(res.Expected)
+
+	for _, mx := range mxs {
+
+		res.Found = append(res.Found, mx.Host)
+
+		if canonicalHost(mx.Host) == want {
+			res.OK = true
+		}
+
+	}
+
+	if !res.OK {
+		res.Problem = "expected MX host not found"
+	}
+
+}
+
+func checkCNAME(ctx context.Context, resolver Resolver, res *RecordResult) {
+
+	target, err := resolver.LookupCNAME(ctx, res.Name)
+
+	if err != nil {
+		res.Problem = lookupProblem(err)
+		return
+	}
+
+	res.Found = []string{target}
+
+	if canonicalHost(target) == canonicalHost(res.Expected) {
+		res.OK = true
+		return
+	}
+
+	res.Problem = "CNAME points to a different target"
+
+}
+
+func lookupProblem(err error) string {
+
+	var dnsErr *net.DNSError
+
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return "record not found"
+	}
+
+	return fmt.Sprintf("lookup failed: %v", err)
+
+}
+
+func fqdn(name, domain string) string {
+
+	name = canonicalHost(name)
+	domain = canonicalHost(domain)
+
+	if name == "" || name == "@" {
+		return domain
+	}
+
+	if name == domain || strings.HasSuffix(name, "."+domain) {
+		return name
+	}
+
+	return name + "." + domain
+
+}
+
+func canonicalHost(h string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(h)), ".")
+}
+
+func normalizeTXT(s string) string {
+	return strings.Join(strings.Fields(strings.Trim(s, `"`)), "")
+}
+
+func containsField(record, field string) bool {
+
+	for _, f := range strings.Fields(record) {
+
+		if strings.EqualFold(f, field) {
+			return true
+		}
+
+	}
+
+	return false
+
+}
@@ -0,0 +1,94 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultDomainCacheTTL = 10 * time.Minute
+
+var ErrDomainNotVerified = errors.New("sending domain is not verified")
+
+type domainCacheEntry struct {
+	verified  bool
+	fetchedAt time.Time
+}
+
+type domainVerifier struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]domainCacheEntry
+}
+
+func WithFromDomainVerification(ttl time.Duration) ClientOption {
+	return func(c *Client) error {
+		if ttl < 0 {
+			return errors.New("domain cache TTL must not be negative")
+		}
+		if ttl == 0 {
+			ttl = DefaultDomainCacheTTL
+		}
+		c.domainVerifier = &domainVerifier{ttl: ttl, entries: map[string]domainCacheEntry{}}
+		return nil
+	}
+}
+
+func (c *Client) ClearDomainCache() {
+
+	if c.domainVerifier == nil {
+		return
+	}
+
+	c.domainVerifier.mu.Lock()
+	c.domainVerifier.entries = map[string]domainCacheEntry{}
+	c.domainVerifier.mu.Unlock()
+
+}
+
+func (c *Client) verifyFromDomain(ctx context.Context, from EmailAddress) error {
+
+	v := c.domainVerifier
+
+	if v == nil || isEstimating(ctx) {
+		return nil
+	}
+
+	at := strings.LastIndex(from.Address, "@")
+
+	if at < 0 {
+		return nil
+	}
+
+	domain := strings.ToLower(strings.TrimSpace(from.Address[at+1:]))
+
+	v.mu.Lock()
+	entry, ok := v.entries[domain]
+	v.mu.Unlock()
+
+	if !ok || time.Since(entry.fetchedAt) > v.ttl {
+
+		d, err := c.GetDomain(ctx, domain)
+
+		if err != nil {
+			return fmt.Errorf("failed to verify sending domain %q: %w", domain, err)
+		}
+
+		entry = domainCacheEntry{verified: d.Verified, fetchedAt: time.Now()}
+
+		v.mu.Lock()
+		v.entries[domain] = entry
+		v.mu.Unlock()
+
+	}
+
+	if !entry.verified {
+		return fmt.Errorf("%w: %q (from %s) must be added and verified in your Maileroo account before sending", ErrDomainNotVerified, domain, from.Address)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,43 @@
+package maileroo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	DNSRecordSPF      = "spf"
+	DNSRecordDKIM     = "dkim"
+	DNSRecordDMARC    = "dmarc"
+	DNSRecordMX       = "mx"
+	DNSRecordTracking = "tracking"
+)
+
+type DNSRecord struct {
+	Purpose  string `json:"purpose"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Priority int    `json:"priority,omitempty"`
+	Valid    bool   `json:"valid"`
+}
+
+type Domain struct {
+	Name       string      `json:"domain"`
+	Verified   bool        `json:"verified"`
+	DNSRecords []DNSRecord `json:"dns_records"`
+}
+
+func (c *Client) GetDomain(ctx context.Context, domain string) (*Domain, error) {
+
+	domain = strings.ToLower(strings.TrimSpace(domain))
+
+	if domain == "" {
+		return nil, newFieldError("domain", FieldErrorRequired, "domain must be a non-empty string")
+	}
+
+	return doJSONData[Domain](ctx, c, http.MethodGet, "domains/"+url.PathEscape(domain), nil)
+
+}
@@ -1,12 +1,23 @@
 package maileroo
 
 import (
+	"errors"
+	"fmt"
+	"net/mail"
 	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxEmailAddressLength = 254
+	MaxDisplayNameLength  = 128
 )
 
 type EmailAddress struct {
 	Address     string  `json:"address"`
 	DisplayName *string `json:"display_name,omitempty"`
+
+	group *RecipientGroup
 }
 
 func NewEmail(address string, displayName string) EmailAddress {
@@ -24,6 +35,50 @@ func NewEmail(address string, displayName string) EmailAddress {
 
 }
 
+func EmailAddressFromMailAddress(addr *mail.Address) EmailAddress {
+
+	if addr == nil {
+		return EmailAddress{}
+	}
+
+	return NewEmail(addr.Address, addr.Name)
+
+}
+
+func EmailAddressesFromMailAddresses(addrs []*mail.Address) []EmailAddress {
+
+	out := make([]EmailAddress, 0, len(addrs))
+
+	for _, a := range addrs {
+
+		if a == nil {
+			continue
+		}
+
+		out = append(out, EmailAddressFromMailAddress(a))
+
+	}
+
+	return out
+
+}
+
+func (e EmailAddress) MailAddress() *mail.Address {
+
+	addr := &mail.Address{Address: e.Address}
+
+	if e.DisplayName != nil {
+		addr.Name = *e.DisplayName
+	}
+
+	return addr
+
+}
+
+func (e EmailAddress) String() string {
+	return e.MailAddress().String()
+}
+
 func (e EmailAddress) ToJSON() map[string]string {
 
 	if e.DisplayName == nil {
@@ -52,3 +107,136 @@ func emailAddressesToJSON(addrs []EmailAddress) []map[string]string {
 	return out
 
 }
+
+func (e EmailAddress) validate(field string) error {
+
+	if strings.TrimSpace(e.Address) == "" {
+		return newFieldError(field, FieldErrorRequired, "%s address is required", field)
+	}
+
+	if e.Address != strings.TrimSpace(e.Address) {
+		return newFieldError(field, FieldErrorInvalid, "%s address must not contain leading or trailing whitespace", field)
+	}
+
+	if containsControl(e.Address) {
+		return newInjectionError(field, field+" address")
+	}
+
+	if e.DisplayName != nil {
+
+		if err := validateDisplayName(field, *e.DisplayName); err != nil {
+			return err
+		}
+
+	}
+
+	if len(e.Address) > MaxEmailAddressLength {
+		return newFieldError(field, FieldErrorTooLong, "%s address must not exceed %d characters", field, MaxEmailAddressLength)
+	}
+
+	parsed, err := mail.ParseAddress(e.Address)
+
+	if err != nil || parsed.Address != e.Address {
+		return newFieldError(field, FieldErrorInvalid, "%s is not a valid email address: %q", field, e.Address)
+	}
+
+	return nil
+
+}
+
+func validateDisplayName(field, name string) error {
+
+	if containsControl(name) {
+		return newInjectionError(field, field+" display_name")
+	}
+
+	if !utf8.ValidString(name) {
+		return newFieldError(field+".display_name", FieldErrorInvalid, "%s display_name must be valid UTF-8", field)
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return newFieldError(field+".display_name", FieldErrorInvalid, "%s display_name must not be blank", field)
+	}
+
+	if utf8.RuneCountInString(name) > MaxDisplayNameLength {
+		return newFieldError(field+".display_name", FieldErrorTooLong, "%s display_name must not exceed %d characters", field, MaxDisplayNameLength)
+	}
+
+	return nil
+
+}
+
+func validateAddressList(addrs []EmailAddress, field string) error {
+
+	for i, a := range addrs {
+
+		if err := a.validate(fmt.Sprintf("%s[%d]", field, i)); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+
+}
+
+func validateAddresses(from EmailAddress, to, cc, bcc, replyTo []EmailAddress, maxRecipients int) error {
+
+	if err := from.validate("from"); err != nil {
+		return err
+	}
+
+	lists := []struct {
+		field string
+		addrs []EmailAddress
+	}{
+		{"to", to},
+		{"cc", cc},
+		{"bcc", bcc},
+		{"reply_to", replyTo},
+	}
+
+	for _, l := range lists {
+
+		if err := validateAddressList(l.addrs, l.field); err != nil {
+			return err
+		}
+
+	}
+
+	seen := map[string]string{}
+
+	for _, l := range lists[:3] {
+
+		for i, a := range l.addrs {
+
+			key := strings.ToLower(a.Address)
+			field := fmt.Sprintf("%s[%d]", l.field, i)
+
+			if prev, ok := seen[key]; ok {
+				return newFieldError(field, FieldErrorDuplicate, "%s duplicates recipient %s: %q", field, prev, a.Address)
+			}
+
+			seen[key] = field
+
+		}
+
+	}
+
+	if maxRecipients > 0 && len(seen) > maxRecipients {
+		return newFieldError("to", FieldErrorTooMany, "message has %d recipients, exceeding the maximum of %d", len(seen), maxRecipients)
+	}
+
+	return nil
+
+}
+
+func WithMaxRecipients(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 0 {
+			return errors.New("max recipients must not be negative")
+		}
+		c.maxRecipients = n
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package maileroo
+
+import (
+	"mime"
+)
+
+func WithEncodedWords() ClientOption {
+	return func(c *Client) error {
+		c.encodedWords = true
+		return nil
+	}
+}
+
+func EncodeWord(s string) string {
+	return mime.QEncoding.Encode("utf-8", s)
+}
+
+func encodePayloadWords(payload map[string]any) {
+
+	if s, ok := payload["subject"].(string); ok {
+		payload["subject"] = EncodeWord(s)
+	}
+
+	if from, ok := payload["from"].(map[string]string); ok {
+		payload["from"] = encodeAddressWords(from)
+	}
+
+	for _, field := range []string{"to", "cc", "bcc", "reply_to"} {
+
+		addrs, ok := payload[field].([]map[string]string)
+
+		if !ok {
+			continue
+		}
+
+		encoded := make([]map[string]string, len(addrs))
+
+		for i, a := range addrs {
+			encoded[i] = encodeAddressWords(a)
+		}
+
+		payload[field] = encoded
+
+	}
+
+	if msgs, ok := payload["messages"].([]map[string]any); ok {
+
+		for _, m := range msgs {
+			encodePayloadWords(m)
+		}
+
+	}
+
+}
+
+func encodeAddressWords(addr map[string]string) map[string]string {
+
+	name, ok := addr["display_name"]
+
+	if !ok {
+		return addr
+	}
+
+	out := make(map[string]string, len(addr))
+
+	for k, v := range addr {
+		out[k] = v
+	}
+
+	out["display_name"] = EncodeWord(name)
+
+	return out
+
+}
@@ -0,0 +1,365 @@
+package maileroo
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultEventTable = "maileroo_events"
+
+const DefaultEventClaimTTL = 5 * time.Minute
+
+type EventStore interface {
+	Save(ctx context.Context, key string, ev EmailEvent) (bool, error)
+	MarkProcessed(ctx context.Context, key string) error
+	Release(ctx context.Context, key string) error
+	IsDuplicate(ctx context.Context, key string) (bool, error)
+}
+
+func EventKey(ev EmailEvent) string {
+
+	if ev.ID != "" {
+		return ev.ID
+	}
+
+	raw := ev.Raw
+
+	if len(raw) == 0 {
+		raw, _ = json.Marshal(ev)
+	}
+
+	sum := sha256.Sum256(raw)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+
+}
+
+func (r *EventRouter) WithStore(store EventStore) *EventRouter {
+
+	r.store = store
+
+	return r
+
+}
+
+func (r *EventRouter) dispatchOnce(ctx context.Context, ev EmailEvent) error {
+
+	key := EventKey(ev)
+
+	claimed, err := r.store.Save(ctx, key, ev)
+
+	if err != nil {
+		return fmt.Errorf("failed to save event %s: %w", key, err)
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	if err := r.dispatch(ctx, ev); err != nil {
+
+		if releaseErr := r.store.Release(context.WithoutCancel(ctx), key); releaseErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to release event %s: %w", key, releaseErr))
+		}
+
+		return err
+
+	}
+
+	if err := r.store.MarkProcessed(ctx, key); err != nil {
+		return fmt.Errorf("failed to mark event %s as processed: %w", key, err)
+	}
+
+	return nil
+
+}
+
+type MemoryEventStore struct {
+	mu       sync.Mutex
+	events   map[string]storedEvent
+	claimTTL time.Duration
+}
+
+type storedEvent struct {
+	event     EmailEvent
+	claimedAt time.Time
+	processed bool
+}
+
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{events: map[string]storedEvent{}, claimTTL: DefaultEventClaimTTL}
+}
+
+func (s *MemoryEventStore) WithClaimTTL(ttl time.Duration) *MemoryEventStore {
+
+	s.claimTTL = ttl
+
+	return s
+
+}
+
+func (s *MemoryEventStore) Save(ctx context.Context, key string, ev EmailEvent) (bool, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	// An unprocessed claim older than the TTL belongs to a handler that crashed
+	// before MarkProcessed or Release, so the redelivery takes it over.
+	if stored, ok := s.events[key]; ok && (stored.processed || now.Sub(stored.claimedAt) < s.claimTTL) {
+		return false, nil
+	}
+
+	s.events[key] = storedEvent{event: ev, claimedAt: now}
+
+	return true, nil
+
+}
+
+func (s *MemoryEventStore) MarkProcessed(ctx context.Context, key string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.events[key]
+
+	if !ok {
+		return fmt.Errorf("event %s was not saved", key)
+	}
+
+	stored.processed = true
+	s.events[key] = stored
+
+	return nil
+
+}
+
+func (s *MemoryEventStore) Release(ctx context.Context, key string) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.events[key]; ok && !stored.processed {
+		delete(s.events, key)
+	}
+
+	return nil
+
+}
+
+func (s *MemoryEventStore) IsDuplicate(ctx context.Context, key string) (bool, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.events[key].processed, nil
+
+}
+
+type SQLPlaceholder int
+
+const (
+	SQLPlaceholderQuestion SQLPlaceholder = iota
+	SQLPlaceholderDollar
+)
+
+type SQLEventStore struct {
+	db          *sql.DB
+	table       string
+	placeholder SQLPlaceholder
+	claimTTL    time.Duration
+}
+
+var sqlIdentRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+
+func NewSQLEventStore(db *sql.DB, table string, placeholder SQLPlaceholder) (*SQLEventStore, error) {
+
+	if db == nil {
+		return nil, errors.New("db must not be nil")
+	}
+
+	if table == "" {
+		table = DefaultEventTable
+	}
+
+	if !sqlIdentRe.MatchString(table) {
+		return nil, fmt.Errorf("table name %q must be a plain SQL identifier", table)
+	}
+
+	if placeholder != SQLPlaceholderQuestion && placeholder != SQLPlaceholderDollar {
+		return nil, errors.New("unknown SQL placeholder style")
+	}
+
+	return &SQLEventStore{db: db, table: table, placeholder: placeholder, claimTTL: DefaultEventClaimTTL}, nil
+
+}
+
+func (s *SQLEventStore) WithClaimTTL(ttl time.Duration) *SQLEventStore {
+
+	s.claimTTL = ttl
+
+	return s
+
+}
+
+func (s *SQLEventStore) Schema() string {
+
+	return "CREATE TABLE IF NOT EXISTS " + s.table + ` (
+	event_key    VARCHAR(255) NOT NULL PRIMARY KEY,
+	event_type   VARCHAR(64)  NOT NULL,
+	reference_id VARCHAR(64)  NOT NULL,
+	payload      TEXT         NOT NULL,
+	received_at  TIMESTAMP    NOT NULL,
+	claimed_at   TIMESTAMP    NOT NULL,
+	processed_at TIMESTAMP    NULL
+)`
+
+}
+
+func (s *SQLEventStore) CreateTable(ctx context.Context) error {
+
+	_, err := s.db.ExecContext(ctx, s.Schema())
+
+	return err
+
+}
+
+func (s *SQLEventStore) query(q string) string {
+
+	if s.placeholder == SQLPlaceholderQuestion {
+		return q
+	}
+
+	var b strings.Builder
+
+	n := 0
+
+	for _, r := range q {
+
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+
+		n++
+		fmt.Fprintf(&b, "$%d", n)
+
+	}
+
+	return b.String()
+
+}
+
+func (s *SQLEventStore) Save(ctx context.Context, key string, ev EmailEvent) (bool, error) {
+
+	payload := []byte(ev.Raw)
+
+	if len(payload) == 0 {
+
+		var err error
+
+		if payload, err = json.Marshal(ev); err != nil {
+			return false, err
+		}
+
+	}
+
+	now := time.Now().UTC()
+
+	_, err := s.db.ExecContext(ctx,
+		s.query("INSERT INTO "+s.table+" (event_key, event_type, reference_id, payload, received_at, claimed_at) VALUES (?, ?, ?, ?, ?, ?)"),
+		key, ev.Type, ev.ReferenceID, string(payload), now, now)
+
+	if err == nil {
+		return true, nil
+	}
+
+	// A stale unprocessed claim is taken over with a conditional update, so only
+	// one of several concurrent redeliveries wins it.
+	res, reclaimErr := s.db.ExecContext(ctx,
+		s.query("UPDATE "+s.table+" SET claimed_at = ? WHERE event_key = ? AND processed_at IS NULL AND claimed_at < ?"),
+		now, key, now.Add(-s.claimTTL))
+
+	if reclaimErr == nil {
+
+		if n, _ := res.RowsAffected(); n == 1 {
+			return true, nil
+		}
+
+	}
+
+	// The primary key makes the insert the claim: if the row exists, another
+	// delivery of the same event got there first.
+	if exists, existsErr := s.exists(ctx, key); existsErr == nil && exists {
+		return false, nil
+	}
+
+	return false, err
+
+}
+
+func (s *SQLEventStore) MarkProcessed(ctx context.Context, key string) error {
+
+	res, err := s.db.ExecContext(ctx, s.query("UPDATE "+s.table+" SET processed_at = ? WHERE event_key = ?"), time.Now().UTC(), key)
+
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("event %s was not saved", key)
+	}
+
+	return nil
+
+}
+
+func (s *SQLEventStore) Release(ctx context.Context, key string) error {
+
+	_, err := s.db.ExecContext(ctx, s.query("DELETE FROM "+s.table+" WHERE event_key = ? AND processed_at IS NULL"), key)
+
+	return err
+
+}
+
+func (s *SQLEventStore) IsDuplicate(ctx context.Context, key string) (bool, error) {
+
+	var processed sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, s.query("SELECT processed_at FROM "+s.table+" WHERE event_key = ?"), key).Scan(&processed)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return processed.Valid, nil
+
+}
+
+func (s *SQLEventStore) exists(ctx context.Context, key string) (bool, error) {
+
+	var one int
+
+	err := s.db.QueryRowContext(ctx, s.query("SELECT 1 FROM "+s.table+" WHERE event_key = ?"), key).Scan(&one)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+
+	return err == nil, err
+
+}
@@ -0,0 +1,238 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	EventDelivered  = "delivered"
+	EventBounced    = "bounced"
+	EventOpened     = "opened"
+	EventClicked    = "clicked"
+	EventComplained = "complained"
+	EventFailed     = "failed"
+)
+
+type EmailEvent struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"event_type"`
+	ReferenceID string          `json:"reference_id"`
+	Recipient   string          `json:"recipient"`
+	BounceType  string          `json:"bounce_type,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Tags        AssocMap        `json:"tags,omitempty"`
+	Timestamp   time.Time       `json:"timestamp"`
+	Data        map[string]any  `json:"data,omitempty"`
+	Raw         json.RawMessage `json:"-"`
+}
+
+func (e *EmailEvent) UnmarshalJSON(b []byte) error {
+
+	type plain EmailEvent
+
+	var aux struct {
+		*plain
+		Timestamp json.RawMessage `json:"timestamp"`
+	}
+
+	aux.plain = (*plain)(e)
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.Timestamp) > 0 {
+		e.Timestamp = parseAPITime(aux.Timestamp)
+	}
+
+	if e.ReferenceID == "" {
+		e.ReferenceID, _ = e.Data["reference_id"].(string)
+	}
+
+	if e.Tags == nil {
+		e.Tags, _ = e.Data["tags"].(map[string]any)
+	}
+
+	e.Raw = append(json.RawMessage(nil), b...)
+
+	return nil
+
+}
+
+func (e EmailEvent) IsKnownType() bool {
+
+	switch e.Type {
+
+	case EventDelivered, EventBounced, EventOpened, EventClicked, EventComplained, EventFailed:
+		return true
+
+	}
+
+	return false
+
+}
+
+func (e EmailEvent) Tag(key string) (string, bool) {
+
+	v, ok := e.Tags[key]
+
+	if !ok || v == nil {
+		return "", false
+	}
+
+	if s, ok := v.(string); ok {
+		return s, true
+	}
+
+	return fmt.Sprint(v), true
+
+}
+
+type EventHandler func(ctx context.Context, ev EmailEvent) error
+
+type EventRouter struct {
+	handlers map[string]EventHandler
+	fallback EventHandler
+	store    EventStore
+}
+
+func NewEventRouter() *EventRouter {
+	return &EventRouter{handlers: map[string]EventHandler{}}
+}
+
+func (r *EventRouter) On(eventType string, h EventHandler) *EventRouter {
+
+	r.handlers[eventType] = h
+
+	return r
+
+}
+
+func (r *EventRouter) OnOther(h EventHandler) *EventRouter {
+
+	r.fallback = h
+
+	return r
+
+}
+
+func (r *EventRouter) Dispatch(ctx context.Context, ev EmailEvent) error {
+
+	if r.store != nil {
+		return r.dispatchOnce(ctx, ev)
+	}
+
+	return r.dispatch(ctx, ev)
+
+}
+
+func (r *EventRouter) dispatch(ctx context.Context, ev EmailEvent) error {
+
+	if h, ok := r.handlers[ev.Type]; ok {
+		return h(ctx, ev)
+	}
+
+	if r.fallback != nil {
+		return r.fallback(ctx, ev)
+	}
+
+	return nil
+
+}
+
+type EmailEventQuery struct {
+	Start       time.Time
+	End         time.Time
+	Types       []string
+	ReferenceID string
+	PerPage     int
+}
+
+type eventsPage struct {
+	Page       int          `json:"page"`
+	PerPage    int          `json:"per_page"`
+	TotalCount int          `json:"total_count"`
+	TotalPages int          `json:"total_pages"`
+	NextCursor string       `json:"next_cursor"`
+	Items      []EmailEvent `json:"results"`
+}
+
+func (c *Client) ExportEmailEvents(ctx context.Context, query EmailEventQuery) (*Iterator[EmailEvent], error) {
+
+	if query.Start.IsZero() || query.End.IsZero() {
+		return nil, newFieldError("start", FieldErrorRequired, "start and end must both be set")
+	}
+
+	if !query.End.After(query.Start) {
+		return nil, newFieldError("end", FieldErrorInvalid, "end must be after start")
+	}
+
+	if query.PerPage == 0 {
+		query.PerPage = 100
+	}
+
+	if query.PerPage < 1 || query.PerPage > 100 {
+		return nil, newFieldError("per_page", FieldErrorInvalid, "per_page must be between 1 and 100")
+	}
+
+	if query.ReferenceID != "" {
+
+		if err := validateReferenceID(query.ReferenceID); err != nil {
+			return nil, err
+		}
+
+	}
+
+	base := url.Values{}
+
+	base.Set("start_date", query.Start.UTC().Format(time.RFC3339))
+	base.Set("end_date", query.End.UTC().Format(time.RFC3339))
+	base.Set("per_page", fmt.Sprintf("%d", query.PerPage))
+
+	if len(query.Types) > 0 {
+		base.Set("event_type", strings.Join(query.Types, ","))
+	}
+
+	if query.ReferenceID != "" {
+		base.Set("reference_id", query.ReferenceID)
+	}
+
+	fetch := func(ctx context.Context, req pageRequest) (pageResult[EmailEvent], error) {
+
+		q := url.Values{}
+
+		for k, v := range base {
+			q[k] = v
+		}
+
+		if req.cursor != "" {
+			q.Set("cursor", req.cursor)
+		} else {
+			q.Set("page", fmt.Sprintf("%d", req.page))
+		}
+
+		page, err := doJSONData[eventsPage](ctx, c, http.MethodGet, "emails/events?"+q.Encode(), nil)
+
+		if err != nil {
+			return pageResult[EmailEvent]{}, err
+		}
+
+		res := pageResult[EmailEvent]{items: page.Items, nextCursor: page.NextCursor}
+
+		if res.nextCursor == "" && page.Page < page.TotalPages {
+			res.nextPage = page.Page + 1
+		}
+
+		return res, nil
+
+	}
+
+	return newIterator(ctx, fetch), nil
+
+}
@@ -0,0 +1,79 @@
+package maileroo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+func jsonFieldNames(v any, extra ...string) map[string]bool {
+
+	names := map[string]bool{}
+
+	t := reflect.TypeOf(v)
+
+	for i := 0; i < t.NumField(); i++ {
+
+		f := t.Field(i)
+
+		if !f.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+
+		switch name {
+
+		case "-":
+			continue
+
+		case "":
+			name = f.Name
+
+		}
+
+		names[name] = true
+
+	}
+
+	for _, name := range extra {
+		names[name] = true
+	}
+
+	return names
+
+}
+
+func unknownFields(raw map[string]json.RawMessage, known map[string]bool) map[string]json.RawMessage {
+
+	var extra map[string]json.RawMessage
+
+	for k, v := range raw {
+
+		if known[k] {
+			continue
+		}
+
+		if extra == nil {
+			extra = map[string]json.RawMessage{}
+		}
+
+		extra[k] = v
+
+	}
+
+	return extra
+
+}
+
+func captureExtra(b []byte, known map[string]bool) map[string]json.RawMessage {
+
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil
+	}
+
+	return unknownFields(raw, known)
+
+}
@@ -0,0 +1,235 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFailoverThreshold = 3
+	DefaultFailoverCooldown  = 30 * time.Second
+	failoverProbeTimeout     = 5 * time.Second
+)
+
+type baseURLState struct {
+	url            string
+	failures       int
+	unhealthyUntil time.Time
+}
+
+type failoverPool struct {
+	mu        sync.Mutex
+	states    []*baseURLState
+	threshold int
+	cooldown  time.Duration
+}
+
+func WithFailoverBaseURLs(urls ...string) ClientOption {
+	return func(c *Client) error {
+		if len(urls) == 0 {
+			return errors.New("at least one failover base URL is required")
+		}
+		for _, u := range urls {
+			if strings.TrimSpace(u) == "" {
+				return errors.New("failover base URLs must be non-empty strings")
+			}
+			if !strings.HasSuffix(u, "/") {
+				u = u + "/"
+			}
+			c.failoverURLs = append(c.failoverURLs, u)
+		}
+		return nil
+	}
+}
+
+func WithFailoverPolicy(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) error {
+		if threshold < 1 {
+			return errors.New("failover threshold must be a positive integer")
+		}
+		if cooldown <= 0 {
+			return errors.New("failover cooldown must be a positive duration")
+		}
+		c.failoverThreshold = threshold
+		c.failoverCooldown = cooldown
+		return nil
+	}
+}
+
+func newFailoverPool(primary string, failovers []string, threshold int, cooldown time.Duration) *failoverPool {
+
+	if threshold < 1 {
+		threshold = DefaultFailoverThreshold
+	}
+
+	if cooldown <= 0 {
+		cooldown = DefaultFailoverCooldown
+	}
+
+	p := &failoverPool{threshold: threshold, cooldown: cooldown}
+
+	for _, u := range append([]string{primary}, failovers...) {
+		p.states = append(p.states, &baseURLState{url: u})
+	}
+
+	return p
+
+}
+
+func (p *failoverPool) size() int {
+	return len(p.states)
+}
+
+func (p *failoverPool) pick(ctx context.Context, hc *http.Client) string {
+
+	now := time.Now()
+
+	p.mu.Lock()
+
+	var recovering []*baseURLState
+
+	for _, s := range p.states {
+
+		if s.unhealthyUntil.IsZero() {
+			p.mu.Unlock()
+			return p.recover(ctx, hc, recovering, s.url)
+		}
+
+		if now.After(s.unhealthyUntil) {
+			recovering = append(recovering, s)
+		}
+
+	}
+
+	fallback := p.states[0]
+
+	for _, s := range p.states[1:] {
+
+		if s.unhealthyUntil.Before(fallback.unhealthyUntil) {
+			fallback = s
+		}
+
+	}
+
+	p.mu.Unlock()
+
+	return p.recover(ctx, hc, recovering, fallback.url)
+
+}
+
+func (p *failoverPool) recover(ctx context.Context, hc *http.Client, candidates []*baseURLState, fallback string) string {
+
+	for _, s := range candidates {
+
+		if probeBaseURL(ctx, hc, s.url) {
+			p.markHealthy(s.url)
+			return s.url
+		}
+
+		p.markUnhealthy(s.url)
+
+	}
+
+	return fallback
+
+}
+
+func (p *failoverPool) report(url string, class ErrorClass, status int) {
+
+	switch {
+
+	case class == ErrorClassNetwork:
+		p.markUnhealthy(url)
+
+	case status >= 500 || class == ErrorClassTimeout:
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		for _, s := range p.states {
+
+			if s.url == url {
+
+				s.failures++
+
+				if s.failures >= p.threshold {
+					s.unhealthyUntil = time.Now().Add(p.cooldown)
+				}
+
+			}
+
+		}
+
+	case class == ErrorClassNone || status > 0:
+		p.markHealthy(url)
+
+	}
+
+}
+
+func (p *failoverPool) markHealthy(url string) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, s := range p.states {
+
+		if s.url == url {
+			s.failures = 0
+			s.unhealthyUntil = time.Time{}
+		}
+
+	}
+
+}
+
+func (p *failoverPool) markUnhealthy(url string) {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, s := range p.states {
+
+		if s.url == url {
+			s.failures = p.threshold
+			s.unhealthyUntil = time.Now().Add(p.cooldown)
+		}
+
+	}
+
+}
+
+func probeBaseURL(ctx context.Context, hc *http.Client, url string) bool {
+
+	ctx, cancel := context.WithTimeout(ctx, failoverProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return false
+	}
+
+	resp, err := hc.Do(req)
+
+	if err != nil {
+		return false
+	}
+
+	resp.Body.Close()
+
+	return resp.StatusCode < 500
+
+}
+
+func isDialError(err error) bool {
+
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+
+}
@@ -0,0 +1,163 @@
+package maileroo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	mathrand "math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type FaultPolicy struct {
+	LatencyRate     float64
+	MaxLatency      time.Duration
+	RateLimitRate   float64
+	ServerErrorRate float64
+	TimeoutRate     float64
+	MalformedRate   float64
+	Seed            int64
+}
+
+type faultInjector struct {
+	policy FaultPolicy
+	mu     sync.Mutex
+	rng    *mathrand.Rand
+}
+
+type faultTransport struct {
+	injector *faultInjector
+	next     http.RoundTripper
+}
+
+func WithFaultInjection(policy FaultPolicy) ClientOption {
+	return func(c *Client) error {
+		if err := policy.validate(); err != nil {
+			return err
+		}
+		seed := policy.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		f := &faultInjector{policy: policy, rng: mathrand.New(mathrand.NewSource(seed))}
+		c.roundTrippers = append(c.roundTrippers, func(next http.RoundTripper) http.RoundTripper {
+			return &faultTransport{injector: f, next: next}
+		})
+		return nil
+	}
+}
+
+func (p FaultPolicy) validate() error {
+
+	for _, rate := range []float64{p.LatencyRate, p.RateLimitRate, p.ServerErrorRate, p.TimeoutRate, p.MalformedRate} {
+
+		if rate < 0 || rate > 1 {
+			return errors.New("fault rates must be between 0 and 1")
+		}
+
+	}
+
+	if p.RateLimitRate+p.ServerErrorRate+p.TimeoutRate+p.MalformedRate > 1 {
+		return errors.New("rate limit, server error, timeout and malformed rates must not add up to more than 1")
+	}
+
+	if p.MaxLatency < 0 {
+		return errors.New("max latency must not be negative")
+	}
+
+	if p.LatencyRate > 0 && p.MaxLatency == 0 {
+		return errors.New("max latency is required when a latency rate is set")
+	}
+
+	return nil
+
+}
+
+func (f *faultInjector) draw() (delay time.Duration, fault float64) {
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.rng.Float64() < f.policy.LatencyRate {
+		delay = time.Duration(f.rng.Int63n(int64(f.policy.MaxLatency)) + 1)
+	}
+
+	return delay, f.rng.Float64()
+
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+
+	p := t.injector.policy
+	delay, fault := t.injector.draw()
+
+	if delay > 0 {
+
+		timer := time.NewTimer(delay)
+
+		select {
+
+		case <-timer.C:
+
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+
+		}
+
+	}
+
+	switch {
+
+	case fault < p.RateLimitRate:
+		return injectedResponse(req, http.StatusTooManyRequests, `{"success":false,"message":"Too many requests (injected fault)."}`), nil
+
+	case fault < p.RateLimitRate+p.ServerErrorRate:
+		return injectedResponse(req, http.StatusServiceUnavailable, `{"success":false,"message":"Service unavailable (injected fault)."}`), nil
+
+	case fault < p.RateLimitRate+p.ServerErrorRate+p.TimeoutRate:
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+
+	case fault < p.RateLimitRate+p.ServerErrorRate+p.TimeoutRate+p.MalformedRate:
+		return injectedResponse(req, http.StatusOK, `{"success":true,"data":{`), nil
+
+	}
+
+	next := t.next
+
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return next.RoundTrip(req)
+
+}
+
+func injectedResponse(req *http.Request, status int, body string) *http.Response {
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	resp := &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+
+	if status == http.StatusTooManyRequests {
+		resp.Header.Set("Retry-After", "1")
+	}
+
+	return resp
+
+}
@@ -0,0 +1,80 @@
+package maileroo
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	FieldErrorRequired  = "required"
+	FieldErrorInvalid   = "invalid"
+	FieldErrorTooLong   = "too_long"
+	FieldErrorTooMany   = "too_many"
+	FieldErrorTooLarge  = "too_large"
+	FieldErrorConflict  = "conflict"
+	FieldErrorDuplicate = "duplicate"
+	FieldErrorInjection = "header_injection"
+)
+
+var ErrHeaderInjection = errors.New("value contains CR, LF or other control characters")
+
+type FieldError struct {
+	Path    string
+	Code    string
+	Message string
+
+	err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.err
+}
+
+func newFieldError(path, code, format string, args ...any) error {
+	return &FieldError{Path: path, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func withFieldPrefix(prefix string, err error) error {
+
+	var fe *FieldError
+
+	if !errors.As(err, &fe) {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+
+	path := prefix
+
+	if fe.Path != "" {
+		path = prefix + "." + fe.Path
+	}
+
+	return &FieldError{Path: path, Code: fe.Code, Message: prefix + ": " + fe.Message, err: fe.err}
+
+}
+
+func newInjectionError(path, field string) error {
+	return &FieldError{
+		Path:    path,
+		Code:    FieldErrorInjection,
+		Message: fmt.Sprintf("%s must not contain CR, LF or other control characters", field),
+		err:     ErrHeaderInjection,
+	}
+}
+
+func containsControl(s string) bool {
+
+	for _, r := range s {
+
+		if (r < 0x20 && r != '\t') || r == 0x7f {
+			return true
+		}
+
+	}
+
+	return false
+
+}
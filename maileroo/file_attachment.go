@@ -0,0 +1,78 @@
+package maileroo
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type FileAttachment struct {
+	Path        string
+	FileName    string
+	ContentType string
+	Inline      bool
+}
+
+func (f FileAttachment) Attachment() (Attachment, error) {
+
+	if strings.TrimSpace(f.Path) == "" {
+		return Attachment{}, errors.New("path must be a readable file")
+	}
+
+	info, err := os.Stat(f.Path)
+
+	if err != nil || info.IsDir() {
+		return Attachment{}, errors.New("path must be a readable file")
+	}
+
+	fileName := f.FileName
+
+	if strings.TrimSpace(fileName) == "" {
+		fileName = filepath.Base(f.Path)
+	}
+
+	ct := f.ContentType
+
+	if strings.TrimSpace(ct) == "" {
+		ct = detectMimeFromPath(f.Path)
+	}
+
+	return Attachment{
+		FileName:    fileName,
+		ContentType: ct,
+		Inline:      f.Inline,
+		path:        f.Path,
+	}, nil
+
+}
+
+func (a Attachment) Load() (Attachment, error) {
+
+	if a.path == "" || a.Content != "" {
+		return a, nil
+	}
+
+	data, err := os.ReadFile(a.path)
+
+	if err != nil {
+		return a, newFieldError("content", FieldErrorInvalid, "failed to read attachment file %q: %v", a.path, err)
+	}
+
+	if strings.TrimSpace(a.ContentType) == "" {
+
+		if d := detectMimeFromBuffer(data); d != "" {
+			a.ContentType = d
+		} else {
+			a.ContentType = "application/octet-stream"
+		}
+
+	}
+
+	a.Content = base64.StdEncoding.EncodeToString(data)
+	a.path = ""
+
+	return a, nil
+
+}
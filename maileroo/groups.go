@@ -0,0 +1,183 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type SuppressionChecker interface {
+	IsSuppressed(ctx context.Context, address string) (bool, error)
+}
+
+type RecipientGroup struct {
+	Name              string
+	ExcludeSuppressed bool
+
+	mu      sync.RWMutex
+	members []EmailAddress
+}
+
+func NewRecipientGroup(name string, members ...EmailAddress) *RecipientGroup {
+	return &RecipientGroup{Name: name, members: append([]EmailAddress(nil), members...)}
+}
+
+func (g *RecipientGroup) Add(members ...EmailAddress) {
+
+	g.mu.Lock()
+	g.members = append(g.members, members...)
+	g.mu.Unlock()
+
+}
+
+func (g *RecipientGroup) Remove(address string) {
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	out := g.members[:0]
+
+	for _, m := range g.members {
+
+		if !strings.EqualFold(m.Address, address) {
+			out = append(out, m)
+		}
+
+	}
+
+	g.members = out
+
+}
+
+func (g *RecipientGroup) Members() []EmailAddress {
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	return append([]EmailAddress(nil), g.members...)
+
+}
+
+func (g *RecipientGroup) Address() EmailAddress {
+	return EmailAddress{group: g}
+}
+
+func (e EmailAddress) IsGroup() bool {
+	return e.group != nil
+}
+
+func WithSuppressionChecker(checker SuppressionChecker) ClientOption {
+	return func(c *Client) error {
+		if checker == nil {
+			return errors.New("suppression checker must not be nil")
+		}
+		c.suppressionChecker = checker
+		return nil
+	}
+}
+
+func (c *Client) expandGroups(ctx context.Context, addrs []EmailAddress) ([]EmailAddress, error) {
+
+	hasGroup := false
+
+	for _, a := range addrs {
+
+		if a.group != nil {
+			hasGroup = true
+			break
+		}
+
+	}
+
+	if !hasGroup {
+		return addrs, nil
+	}
+
+	return c.expandGroupList(ctx, addrs, map[*RecipientGroup]bool{}, false)
+
+}
+
+func (c *Client) expandGroupList(ctx context.Context, addrs []EmailAddress, visiting map[*RecipientGroup]bool, excludeSuppressed bool) ([]EmailAddress, error) {
+
+	out := make([]EmailAddress, 0, len(addrs))
+
+	for _, a := range addrs {
+
+		if a.group == nil {
+
+			// Estimates keep suppressed recipients rather than consult the checker,
+			// so they are an upper bound.
+			if excludeSuppressed && !isEstimating(ctx) {
+
+				suppressed, err := c.suppressionChecker.IsSuppressed(ctx, a.Address)
+
+				if err != nil {
+					return nil, fmt.Errorf("failed to check suppression status of %q: %w", a.Address, err)
+				}
+
+				if suppressed {
+					continue
+				}
+
+			}
+
+			out = append(out, a)
+			continue
+
+		}
+
+		g := a.group
+
+		if visiting[g] {
+			return nil, fmt.Errorf("recipient group %q contains itself", g.Name)
+		}
+
+		exclude := excludeSuppressed || g.ExcludeSuppressed
+
+		if exclude && c.suppressionChecker == nil {
+			return nil, fmt.Errorf("recipient group %q excludes suppressed members but no suppression checker is configured", g.Name)
+		}
+
+		visiting[g] = true
+		members, err := c.expandGroupList(ctx, g.Members(), visiting, exclude)
+		delete(visiting, g)
+
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, members...)
+
+	}
+
+	return out, nil
+
+}
+
+func (c *Client) expandBulkGroups(ctx context.Context, in []BulkMessage) ([]BulkMessage, error) {
+
+	out := make([]BulkMessage, len(in))
+
+	for i, m := range in {
+
+		for _, list := range []*[]EmailAddress{&m.To, &m.Cc, &m.Bcc, &m.ReplyTo} {
+
+			expanded, err := c.expandGroups(ctx, *list)
+
+			if err != nil {
+				return nil, withFieldPrefix(fmt.Sprintf("messages[%d]", i), err)
+			}
+
+			*list = expanded
+
+		}
+
+		out[i] = m
+
+	}
+
+	return out, nil
+
+}
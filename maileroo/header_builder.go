@@ -0,0 +1,248 @@
+package maileroo
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	AutoSubmittedNo            = "no"
+	AutoSubmittedAutoGenerated = "auto-generated"
+	AutoSubmittedAutoReplied   = "auto-replied"
+)
+
+type HeaderBuilder struct {
+	headers AssocMap
+	err     error
+}
+
+func NewHeaderBuilder() *HeaderBuilder {
+	return &HeaderBuilder{headers: AssocMap{}}
+}
+
+func (b *HeaderBuilder) Set(name, value string) *HeaderBuilder {
+
+	if b.err != nil {
+		return b
+	}
+
+	if err := validateHeaderName(name); err != nil {
+		b.err = err
+		return b
+	}
+
+	if err := validateHeaderValue(name, value); err != nil {
+		b.err = err
+		return b
+	}
+
+	for existing := range b.headers {
+
+		if strings.EqualFold(existing, name) && existing != name {
+			delete(b.headers, existing)
+		}
+
+	}
+
+	b.headers[name] = value
+
+	return b
+
+}
+
+func (b *HeaderBuilder) ListID(description, id string) *HeaderBuilder {
+
+	if strings.TrimSpace(id) == "" {
+		return b.fail(newFieldError("headers.List-Id", FieldErrorRequired, "List-Id requires a non-empty id"))
+	}
+
+	if strings.ContainsAny(id, "<> ") {
+		return b.fail(fmt.Errorf("List-Id id %q must not contain spaces or angle brackets", id))
+	}
+
+	value := "<" + id + ">"
+
+	if description = strings.TrimSpace(description); description != "" {
+		value = quoteHeaderPhrase(description) + " " + value
+	}
+
+	return b.Set("List-Id", value)
+
+}
+
+func (b *HeaderBuilder) ListUnsubscribe(urls ...string) *HeaderBuilder {
+
+	if len(urls) == 0 {
+		return b.fail(newFieldError("headers.List-Unsubscribe", FieldErrorRequired, "List-Unsubscribe requires at least one URL"))
+	}
+
+	parts := make([]string, 0, len(urls))
+
+	for _, u := range urls {
+
+		if !strings.HasPrefix(u, "https://") && !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "mailto:") {
+			return b.fail(fmt.Errorf("List-Unsubscribe URL %q must be an http(s) or mailto URL", u))
+		}
+
+		parts = append(parts, "<"+u+">")
+
+	}
+
+	return b.Set("List-Unsubscribe", strings.Join(parts, ", "))
+
+}
+
+func (b *HeaderBuilder) ListUnsubscribePost() *HeaderBuilder {
+	return b.Set("List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+}
+
+func (b *HeaderBuilder) AutoSubmitted(value string) *HeaderBuilder {
+
+	switch value {
+
+	case AutoSubmittedNo, AutoSubmittedAutoGenerated, AutoSubmittedAutoReplied:
+		return b.Set("Auto-Submitted", value)
+
+	}
+
+	return b.fail(fmt.Errorf("Auto-Submitted must be one of %q, %q or %q", AutoSubmittedNo, AutoSubmittedAutoGenerated, AutoSubmittedAutoReplied))
+
+}
+
+func (b *HeaderBuilder) ReturnPath(addr EmailAddress) *HeaderBuilder {
+
+	if err := addr.validate("Return-Path"); err != nil {
+		return b.fail(err)
+	}
+
+	return b.Set("Return-Path", "<"+addr.Address+">")
+
+}
+
+func (b *HeaderBuilder) RequestReadReceipt(addr EmailAddress) *HeaderBuilder {
+
+	if addr.IsGroup() {
+		return b.fail(newFieldError("headers.Disposition-Notification-To", FieldErrorInvalid, "Disposition-Notification-To must be a single address, not a recipient group"))
+	}
+
+	if err := addr.validate("Disposition-Notification-To"); err != nil {
+		return b.fail(err)
+	}
+
+	value := addr.String()
+
+	return b.Set("Disposition-Notification-To", value).Set("Return-Receipt-To", value)
+
+}
+
+func (b *HeaderBuilder) EntityRefID(id string) *HeaderBuilder {
+
+	if strings.TrimSpace(id) == "" {
+		return b.fail(newFieldError("headers.X-Entity-Ref-ID", FieldErrorRequired, "X-Entity-Ref-ID requires a non-empty id"))
+	}
+
+	return b.Set("X-Entity-Ref-ID", id)
+
+}
+
+func (b *HeaderBuilder) Priority(p Priority) *HeaderBuilder {
+
+	ph := PriorityHeaders(p)
+
+	if ph == nil {
+		return b.fail(fmt.Errorf("unknown priority %d", p))
+	}
+
+	for k, v := range ph {
+		b.Set(k, v.(string))
+	}
+
+	return b
+
+}
+
+func (b *HeaderBuilder) Precedence(precedence string) *HeaderBuilder {
+
+	h, err := PrecedenceHeader(precedence)
+
+	if err != nil {
+		return b.fail(err)
+	}
+
+	return b.Set("Precedence", h["Precedence"].(string))
+
+}
+
+func (b *HeaderBuilder) Build() (AssocMap, error) {
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	out := make(AssocMap, len(b.headers))
+
+	for k, v := range b.headers {
+		out[k] = v
+	}
+
+	return out, nil
+
+}
+
+func (b *HeaderBuilder) fail(err error) *HeaderBuilder {
+
+	if b.err == nil {
+		b.err = err
+	}
+
+	return b
+
+}
+
+func validateHeaderName(name string) error {
+
+	if name == "" {
+		return newFieldError("headers", FieldErrorInvalid, "header names must be non-empty")
+	}
+
+	for i := 0; i < len(name); i++ {
+
+		c := name[i]
+
+		if c < 32 || c == 127 {
+			return newInjectionError("headers."+name, "header name")
+		}
+
+		if c == ' ' || c > 126 || c == ':' {
+			return newFieldError("headers."+name, FieldErrorInvalid, "header name %q contains characters not allowed in a header field name", name)
+		}
+
+	}
+
+	return nil
+
+}
+
+func validateHeaderValue(name, value string) error {
+
+	if containsControl(value) {
+		return newInjectionError("headers."+name, "header "+name+" value")
+	}
+
+	return nil
+
+}
+
+func quoteHeaderPhrase(s string) string {
+
+	for _, r := range s {
+
+		if r < 0x20 || r > 0x7e || strings.ContainsRune(`()<>[]:;@\,."`, r) {
+			return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+		}
+
+	}
+
+	return s
+
+}
@@ -0,0 +1,161 @@
+package maileroo
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+type Priority int
+
+const (
+	PriorityUnset Priority = iota
+	PriorityHigh
+	PriorityNormal
+	PriorityLow
+)
+
+const (
+	PrecedenceBulk = "bulk"
+	PrecedenceList = "list"
+	PrecedenceJunk = "junk"
+)
+
+var xPriorityRe = regexp.MustCompile(`^[1-5](\s+\([A-Za-z]+\))?$`)
+
+func PriorityHeaders(p Priority) AssocMap {
+
+	switch p {
+
+	case PriorityHigh:
+		return AssocMap{"X-Priority": "1 (Highest)", "Importance": "high"}
+
+	case PriorityNormal:
+		return AssocMap{"X-Priority": "3 (Normal)", "Importance": "normal"}
+
+	case PriorityLow:
+		return AssocMap{"X-Priority": "5 (Lowest)", "Importance": "low"}
+
+	}
+
+	return nil
+
+}
+
+func PrecedenceHeader(precedence string) (AssocMap, error) {
+
+	switch precedence {
+
+	case PrecedenceBulk, PrecedenceList, PrecedenceJunk:
+		return AssocMap{"Precedence": precedence}, nil
+
+	}
+
+	return nil, fmt.Errorf("precedence must be one of %q, %q or %q", PrecedenceBulk, PrecedenceList, PrecedenceJunk)
+
+}
+
+func applyPriority(headers AssocMap, p Priority) (AssocMap, error) {
+
+	if p == PriorityUnset {
+		return headers, nil
+	}
+
+	ph := PriorityHeaders(p)
+
+	if ph == nil {
+		return nil, fmt.Errorf("unknown priority %d", p)
+	}
+
+	out := make(AssocMap, len(headers)+len(ph))
+
+	for k, v := range headers {
+		out[k] = v
+	}
+
+	for k, v := range ph {
+
+		for existing := range headers {
+
+			if strings.EqualFold(existing, k) {
+				return nil, newFieldError("priority", FieldErrorConflict, "priority conflicts with the explicit %s header", existing)
+			}
+
+		}
+
+		out[k] = v
+
+	}
+
+	return out, nil
+
+}
+
+func validateWellKnownHeaders(headers AssocMap) error {
+
+	seen := map[string]string{}
+
+	for k, v := range headers {
+
+		lower := strings.ToLower(k)
+
+		if prev, ok := seen[lower]; ok {
+			return newFieldError("headers."+k, FieldErrorDuplicate, "headers contain %q and %q, which differ only in case", prev, k)
+		}
+
+		seen[lower] = k
+
+		if err := validateHeaderName(k); err != nil {
+			return err
+		}
+
+		if err := validateHeaderValue(k, fmt.Sprintf("%v", v)); err != nil {
+			return err
+		}
+
+		s := strings.TrimSpace(fmt.Sprintf("%v", v))
+
+		switch lower {
+
+		case "x-priority":
+
+			if !xPriorityRe.MatchString(s) {
+				return newFieldError("headers."+k, FieldErrorInvalid, "header %s must be a number from 1 (highest) to 5 (lowest)", k)
+			}
+
+		case "importance":
+
+			switch strings.ToLower(s) {
+
+			case "high", "normal", "low":
+
+			default:
+				return newFieldError("headers."+k, FieldErrorInvalid, "header %s must be one of high, normal or low", k)
+
+			}
+
+		case "disposition-notification-to", "return-receipt-to":
+
+			if _, err := mail.ParseAddress(s); err != nil {
+				return newFieldError("headers."+k, FieldErrorInvalid, "header %s must be a single valid email address", k)
+			}
+
+		case "precedence":
+
+			switch strings.ToLower(s) {
+
+			case PrecedenceBulk, PrecedenceList, PrecedenceJunk:
+
+			default:
+				return newFieldError("headers."+k, FieldErrorInvalid, "header %s must be one of bulk, list or junk", k)
+
+			}
+
+		}
+
+	}
+
+	return nil
+
+}
@@ -0,0 +1,196 @@
+package maileroo
+
+import (
+	"context"
+)
+
+const MaxIteratorPrefetch = 8
+
+type pageRequest struct {
+	page   int
+	cursor string
+}
+
+type pageResult[T any] struct {
+	items      []T
+	nextPage   int
+	nextCursor string
+}
+
+type pageFetcher[T any] func(ctx context.Context, req pageRequest) (pageResult[T], error)
+
+type pageOutcome[T any] struct {
+	res pageResult[T]
+	err error
+}
+
+type Iterator[T any] struct {
+	ctx      context.Context
+	fetch    pageFetcher[T]
+	next     pageRequest
+	buf      []T
+	cur      T
+	count    int
+	done     bool
+	err      error
+	prefetch int
+	pages    chan pageOutcome[T]
+	cancel   context.CancelFunc
+}
+
+func newIterator[T any](ctx context.Context, fetch pageFetcher[T]) *Iterator[T] {
+	return &Iterator[T]{ctx: ctx, fetch: fetch, next: pageRequest{page: 1}}
+}
+
+func (it *Iterator[T]) Prefetch(pages int) *Iterator[T] {
+
+	if it.pages != nil || it.count > 0 {
+		return it
+	}
+
+	it.prefetch = min(max(pages, 0), MaxIteratorPrefetch)
+
+	return it
+
+}
+
+func (it *Iterator[T]) Next() bool {
+
+	for len(it.buf) == 0 {
+
+		if it.done || it.err != nil {
+			return false
+		}
+
+		if err := it.ctx.Err(); err != nil {
+			it.err = newPartialResult(nil, it.count, 0, err)
+			it.Close()
+			return false
+		}
+
+		res, ok, err := it.nextPage()
+
+		if err != nil {
+			it.err = err
+			it.Close()
+			return false
+		}
+
+		if !ok {
+			it.done = true
+			it.Close()
+			return false
+		}
+
+		it.buf = res.items
+
+		if res.nextCursor == "" && res.nextPage <= 0 {
+			it.done = true
+			it.Close()
+		}
+
+	}
+
+	it.cur = it.buf[0]
+	it.buf = it.buf[1:]
+	it.count++
+
+	return true
+
+}
+
+func (it *Iterator[T]) nextPage() (pageResult[T], bool, error) {
+
+	if it.prefetch == 0 {
+
+		res, err := it.fetch(it.ctx, it.next)
+
+		if err != nil {
+			return res, false, err
+		}
+
+		it.next = nextPageRequest(res)
+
+		return res, true, nil
+
+	}
+
+	if it.pages == nil {
+		it.startPrefetch()
+	}
+
+	select {
+
+	case out, ok := <-it.pages:
+		return out.res, ok, out.err
+
+	case <-it.ctx.Done():
+		return pageResult[T]{}, false, newPartialResult(nil, it.count, 0, it.ctx.Err())
+
+	}
+
+}
+
+func (it *Iterator[T]) startPrefetch() {
+
+	ctx, cancel := context.WithCancel(it.ctx)
+
+	pages := make(chan pageOutcome[T], it.prefetch)
+
+	it.cancel = cancel
+	it.pages = pages
+
+	go func(req pageRequest) {
+
+		defer close(pages)
+
+		for {
+
+			res, err := it.fetch(ctx, req)
+
+			select {
+
+			case pages <- pageOutcome[T]{res: res, err: err}:
+
+			case <-ctx.Done():
+				return
+
+			}
+
+			if err != nil || (res.nextCursor == "" && res.nextPage <= 0) {
+				return
+			}
+
+			req = nextPageRequest(res)
+
+		}
+
+	}(it.next)
+
+}
+
+func nextPageRequest[T any](res pageResult[T]) pageRequest {
+
+	if res.nextCursor != "" {
+		return pageRequest{cursor: res.nextCursor}
+	}
+
+	return pageRequest{page: res.nextPage}
+
+}
+
+func (it *Iterator[T]) Item() T {
+	return it.cur
+}
+
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+func (it *Iterator[T]) Close() {
+
+	if it.cancel != nil {
+		it.cancel()
+	}
+
+}
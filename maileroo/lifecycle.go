@@ -0,0 +1,275 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrClientClosed = errors.New("client is closed")
+
+type lifecycle struct {
+	mu      sync.Mutex
+	parent  *lifecycle
+	closed  bool
+	active  int
+	idle    chan struct{}
+	senders map[*AsyncSender]struct{}
+
+	stop     context.Context
+	stopFunc context.CancelFunc
+
+	abort     context.Context
+	abortFunc context.CancelFunc
+}
+
+type drainKey struct{}
+
+func newLifecycle() *lifecycle {
+
+	l := &lifecycle{senders: map[*AsyncSender]struct{}{}}
+
+	l.stop, l.stopFunc = context.WithCancel(context.Background())
+	l.abort, l.abortFunc = context.WithCancel(context.Background())
+
+	return l
+
+}
+
+func (l *lifecycle) child() *lifecycle {
+
+	if l == nil {
+		return nil
+	}
+
+	c := newLifecycle()
+	c.parent = l
+
+	return c
+
+}
+
+func (l *lifecycle) acquire(ctx context.Context) (context.Context, func(), error) {
+
+	if l == nil {
+		return ctx, func() {}, nil
+	}
+
+	draining := ctx.Value(drainKey{}) != nil
+
+	var held []*lifecycle
+
+	release := func() {
+
+		for _, h := range held {
+			h.done()
+		}
+
+	}
+
+	for p := l; p != nil; p = p.parent {
+
+		p.mu.Lock()
+
+		if p.closed && !draining {
+			p.mu.Unlock()
+			release()
+			return ctx, nil, ErrClientClosed
+		}
+
+		p.active++
+		p.mu.Unlock()
+
+		held = append(held, p)
+
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stops := make([]func() bool, len(held))
+
+	for i, h := range held {
+		stops[i] = context.AfterFunc(h.abort, cancel)
+	}
+
+	return ctx, func() {
+
+		for _, stop := range stops {
+			stop()
+		}
+
+		cancel()
+		release()
+
+	}, nil
+
+}
+
+func (l *lifecycle) done() {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active--
+
+	if l.active == 0 && l.idle != nil {
+		close(l.idle)
+		l.idle = nil
+	}
+
+}
+
+func (l *lifecycle) background(ctx context.Context) (context.Context, func(), error) {
+
+	ctx, release, err := l.acquire(ctx)
+
+	if err != nil || l == nil {
+		return ctx, release, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var stops []func() bool
+
+	for p := l; p != nil; p = p.parent {
+		stops = append(stops, context.AfterFunc(p.stop, cancel))
+	}
+
+	return ctx, func() {
+
+		for _, stop := range stops {
+			stop()
+		}
+
+		cancel()
+		release()
+
+	}, nil
+
+}
+
+func (l *lifecycle) register(s *AsyncSender) error {
+
+	if l == nil {
+		return nil
+	}
+
+	for p := l; p != nil; p = p.parent {
+
+		p.mu.Lock()
+
+		if p.closed {
+			p.mu.Unlock()
+			l.unregister(s)
+			return ErrClientClosed
+		}
+
+		p.senders[s] = struct{}{}
+		p.mu.Unlock()
+
+	}
+
+	return nil
+
+}
+
+func (l *lifecycle) unregister(s *AsyncSender) {
+
+	for p := l; p != nil; p = p.parent {
+		p.mu.Lock()
+		delete(p.senders, s)
+		p.mu.Unlock()
+	}
+
+}
+
+func (l *lifecycle) wait() <-chan struct{} {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active == 0 {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+
+	if l.idle == nil {
+		l.idle = make(chan struct{})
+	}
+
+	return l.idle
+
+}
+
+func (c *Client) Close(ctx context.Context) error {
+
+	l := c.lifecycle
+
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+
+	l.closed = true
+
+	senders := make([]*AsyncSender, 0, len(l.senders))
+
+	for s := range l.senders {
+		senders = append(senders, s)
+	}
+
+	l.mu.Unlock()
+
+	l.stopFunc()
+
+	errs := make([]error, len(senders))
+
+	var wg sync.WaitGroup
+
+	for i, s := range senders {
+
+		wg.Add(1)
+
+		go func(i int, s *AsyncSender) {
+			defer wg.Done()
+			errs[i] = s.Close(ctx)
+		}(i, s)
+
+	}
+
+	wg.Wait()
+
+	select {
+
+	case <-l.wait():
+
+	case <-ctx.Done():
+		l.abortFunc()
+		<-l.wait()
+		return ctx.Err()
+
+	}
+
+	return errors.Join(errs...)
+
+}
+
+func (c *Client) Closed() bool {
+
+	for l := c.lifecycle; l != nil; l = l.parent {
+
+		l.mu.Lock()
+		closed := l.closed
+		l.mu.Unlock()
+
+		if closed {
+			return true
+		}
+
+	}
+
+	return false
+
+}
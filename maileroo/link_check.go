@@ -0,0 +1,337 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	DefaultLinkProbeBudget  = 50
+	DefaultLinkProbeTimeout = 5 * time.Second
+	linkProbeConcurrency    = 4
+)
+
+type LinkCheckPolicy struct {
+	Strict       bool
+	Probe        bool
+	MaxProbes    int
+	ProbeTimeout time.Duration
+	HTTPClient   *http.Client
+}
+
+type LinkIssue struct {
+	URL        string
+	Message    string
+	StatusCode int
+}
+
+type LinkCheckError struct {
+	Issues []LinkIssue
+}
+
+func (e *LinkCheckError) Error() string {
+
+	msgs := make([]string, 0, len(e.Issues))
+
+	for _, issue := range e.Issues {
+		msgs = append(msgs, issue.URL+": "+issue.Message)
+	}
+
+	return "broken links: " + strings.Join(msgs, "; ")
+
+}
+
+var mergeTagRe = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+
+func WithLinkCheck(policy LinkCheckPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxProbes < 0 || policy.ProbeTimeout < 0 {
+			return errors.New("link probe budget and timeout must not be negative")
+		}
+		if policy.MaxProbes == 0 {
+			policy.MaxProbes = DefaultLinkProbeBudget
+		}
+		if policy.ProbeTimeout == 0 {
+			policy.ProbeTimeout = DefaultLinkProbeTimeout
+		}
+		c.linkCheck = &policy
+		return nil
+	}
+}
+
+func CheckLinks(ctx context.Context, htmlBody string, policy LinkCheckPolicy) []LinkIssue {
+
+	var issues []LinkIssue
+	var probe []string
+
+	seen := map[string]bool{}
+
+	for _, href := range extractHrefs(htmlBody) {
+
+		if seen[href] {
+			continue
+		}
+
+		seen[href] = true
+
+		if msg := checkLinkSyntax(href); msg != "" {
+			issues = append(issues, LinkIssue{URL: href, Message: msg})
+			continue
+		}
+
+		if policy.Probe && !strings.Contains(href, "{{") && (strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://")) {
+			probe = append(probe, href)
+		}
+
+	}
+
+	if len(probe) == 0 {
+		return issues
+	}
+
+	budget := policy.MaxProbes
+
+	if budget <= 0 {
+		budget = DefaultLinkProbeBudget
+	}
+
+	if len(probe) > budget {
+		probe = probe[:budget]
+	}
+
+	return append(issues, probeLinks(ctx, probe, policy)...)
+
+}
+
+func extractHrefs(s string) []string {
+
+	z := html.NewTokenizer(strings.NewReader(s))
+
+	var hrefs []string
+
+	for {
+
+		switch z.Next() {
+
+		case html.ErrorToken:
+			return hrefs
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+
+			tok := z.Token()
+
+			if tok.DataAtom != atom.A && tok.DataAtom != atom.Area {
+				continue
+			}
+
+			for _, attr := range tok.Attr {
+
+				if attr.Key == "href" {
+					hrefs = append(hrefs, strings.TrimSpace(attr.Val))
+				}
+
+			}
+
+		}
+
+	}
+
+}
+
+func checkLinkSyntax(href string) string {
+
+	if href == "" {
+		return "link has an empty href"
+	}
+
+	if strings.HasPrefix(href, "#") || mergeTagRe.MatchString(href) && mergeTagRe.ReplaceAllString(href, "") == "" {
+		return ""
+	}
+
+	if strings.Contains(href, "{{") != strings.Contains(href, "}}") {
+		return "link has an unclosed merge tag"
+	}
+
+	u, err := url.Parse(mergeTagRe.ReplaceAllString(href, "x"))
+
+	if err != nil {
+		return "link is not a valid URL"
+	}
+
+	switch strings.ToLower(u.Scheme) {
+
+	case "http", "https":
+
+		if u.Host == "" {
+			return "link has no host"
+		}
+
+		if strings.ContainsAny(href, " \t") {
+			return "link contains whitespace"
+		}
+
+	case "mailto":
+
+		if u.Opaque == "" {
+			return "mailto link has no address"
+		}
+
+	case "tel", "sms":
+
+	case "":
+		return "link is relative, which has no base in an email"
+
+	default:
+		return fmt.Sprintf("link uses unsupported scheme %q", u.Scheme)
+
+	}
+
+	return ""
+
+}
+
+func probeLinks(ctx context.Context, links []string, policy LinkCheckPolicy) []LinkIssue {
+
+	client := policy.HTTPClient
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	timeout := policy.ProbeTimeout
+
+	if timeout <= 0 {
+		timeout = DefaultLinkProbeTimeout
+	}
+
+	results := make([]*LinkIssue, len(links))
+	sem := make(chan struct{}, linkProbeConcurrency)
+
+	var wg sync.WaitGroup
+
+	for i, link := range links {
+
+		wg.Add(1)
+
+		go func(i int, link string) {
+
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = probeLink(ctx, client, link, timeout)
+
+		}(i, link)
+
+	}
+
+	wg.Wait()
+
+	var issues []LinkIssue
+
+	for _, r := range results {
+
+		if r != nil {
+			issues = append(issues, *r)
+		}
+
+	}
+
+	return issues
+
+}
+
+func probeLink(ctx context.Context, client *http.Client, link string, timeout time.Duration) *LinkIssue {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status, err := probeLinkMethod(ctx, client, http.MethodHead, link)
+
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = probeLinkMethod(ctx, client, http.MethodGet, link)
+	}
+
+	if err != nil {
+		return &LinkIssue{URL: link, Message: "link could not be reached: " + err.Error()}
+	}
+
+	if status >= 400 {
+		return &LinkIssue{URL: link, Message: fmt.Sprintf("link returned %d %s", status, http.StatusText(status)), StatusCode: status}
+	}
+
+	return nil
+
+}
+
+func probeLinkMethod(ctx context.Context, client *http.Client, method, link string) (int, error) {
+
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	resp.Body.Close()
+
+	return resp.StatusCode, nil
+
+}
+
+func (c *Client) checkLinks(ctx context.Context, htmlBody *string) error {
+
+	if c.linkCheck == nil || htmlBody == nil {
+		return nil
+	}
+
+	if bulkPrechecked(ctx) {
+		return nil
+	}
+
+	policy := *c.linkCheck
+
+	if isEstimating(ctx) {
+		policy.Probe = false
+	}
+
+	issues := CheckLinks(ctx, *htmlBody, policy)
+
+	if len(issues) == 0 {
+		return nil
+	}
+
+	if policy.Strict {
+		return &LinkCheckError{Issues: issues}
+	}
+
+	logger := c.logger
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	for _, issue := range issues {
+		logger.WarnContext(ctx, "maileroo: link check", slog.String("url", issue.URL), slog.String("issue", issue.Message))
+	}
+
+	return nil
+
+}
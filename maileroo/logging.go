@@ -0,0 +1,259 @@
+package maileroo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type AddressRedaction int
+
+const (
+	AddressRedactionMask AddressRedaction = iota
+	AddressRedactionHash
+	AddressRedactionNone
+)
+
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		if l == nil {
+			return errors.New("logger must not be nil")
+		}
+		c.logger = l
+		return nil
+	}
+}
+
+func WithAddressRedaction(mode AddressRedaction) ClientOption {
+	return func(c *Client) error {
+		if mode < AddressRedactionMask || mode > AddressRedactionNone {
+			return errors.New("unknown address redaction mode")
+		}
+		c.addressRedaction = mode
+		return nil
+	}
+}
+
+func (c *Client) logRequestStart(ctx context.Context, method, endpoint string, body []byte) {
+
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []any{
+		slog.String("method", method),
+		slog.String("endpoint", c.redactEndpoint(endpoint)),
+		slog.String("api_key", redactAPIKey(c.APIKey)),
+	}
+
+	if body != nil {
+		attrs = append(attrs, slog.Any("body", c.redactBody(body)))
+	}
+
+	attrs = append(attrs, contextTagAttrs(ContextTagsFrom(ctx))...)
+
+	c.logger.DebugContext(ctx, "maileroo: sending request", attrs...)
+
+}
+
+func (c *Client) logRequestEnd(ctx context.Context, ev RequestEvent, err error) {
+
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []any{
+		slog.String("method", ev.Method),
+		slog.String("endpoint", ev.Endpoint),
+		slog.Int("status", ev.StatusCode),
+		slog.Duration("duration", ev.Duration.Round(time.Millisecond)),
+	}
+
+	attrs = append(attrs, contextTagAttrs(ev.Tags)...)
+
+	switch {
+
+	case err != nil:
+		attrs = append(attrs, slog.String("error_class", string(ev.ErrorClass)), slog.String("error", c.redactString(err.Error())))
+		c.logger.ErrorContext(ctx, "maileroo: request failed", attrs...)
+
+	case ev.ErrorClass != ErrorClassNone:
+		attrs = append(attrs, slog.String("error_class", string(ev.ErrorClass)))
+		c.logger.ErrorContext(ctx, "maileroo: API returned an error", attrs...)
+
+	default:
+		c.logger.InfoContext(ctx, "maileroo: request completed", attrs...)
+
+	}
+
+}
+
+func (c *Client) redactBody(body []byte) any {
+
+	var v any
+
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("[%d bytes]", len(body))
+	}
+
+	return c.redactValue("", v)
+
+}
+
+func (c *Client) redactValue(key string, v any) any {
+
+	switch t := v.(type) {
+
+	case map[string]any:
+
+		out := make(map[string]any, len(t))
+
+		for k, val := range t {
+			out[k] = c.redactValue(k, val)
+		}
+
+		return out
+
+	case []any:
+
+		out := make([]any, len(t))
+
+		for i, val := range t {
+			out[i] = c.redactValue(key, val)
+		}
+
+		return out
+
+	case string:
+
+		switch key {
+
+		case "address":
+			return c.redactAddress(t)
+
+		case "display_name":
+
+			if c.addressRedaction == AddressRedactionNone {
+				return t
+			}
+
+			return "[redacted]"
+
+		case "content":
+
+			if c.attachmentChecksums {
+
+				if raw, err := base64.StdEncoding.DecodeString(t); err == nil {
+					return fmt.Sprintf("[redacted %d bytes sha256:%s]", len(t), checksumBytes(raw).SHA256)
+				}
+
+			}
+
+			return fmt.Sprintf("[redacted %d bytes]", len(t))
+
+		}
+
+		return t
+
+	default:
+		return v
+
+	}
+
+}
+
+func (c *Client) redactAddress(addr string) string {
+
+	switch c.addressRedaction {
+
+	case AddressRedactionNone:
+		return addr
+
+	case AddressRedactionHash:
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(addr))))
+		return "sha256:" + hex.EncodeToString(sum[:])[:16]
+
+	default:
+
+		if i := strings.LastIndexByte(addr, '@'); i >= 0 {
+			return "***" + addr[i:]
+		}
+
+		return "***"
+
+	}
+
+}
+
+func (c *Client) redactEndpoint(endpoint string) string {
+
+	path, query, ok := strings.Cut(endpoint, "?")
+
+	if !ok {
+		return endpoint
+	}
+
+	return path + "?" + c.redactQuery(query)
+
+}
+
+func (c *Client) redactQuery(query string) string {
+
+	pairs := strings.Split(query, "&")
+
+	for i, pair := range pairs {
+
+		key, value, ok := strings.Cut(pair, "=")
+
+		if !ok {
+			continue
+		}
+
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+
+		if strings.Contains(value, "@") {
+			pairs[i] = key + "=" + c.redactAddress(value)
+		}
+
+	}
+
+	return strings.Join(pairs, "&")
+
+}
+
+var queryStringRe = regexp.MustCompile(`\?[^\s"'?]+`)
+
+func (c *Client) redactString(s string) string {
+
+	s = queryStringRe.ReplaceAllStringFunc(s, func(q string) string {
+		return "?" + c.redactQuery(q[1:])
+	})
+
+	if c.APIKey == "" {
+		return s
+	}
+
+	return strings.ReplaceAll(s, c.APIKey, redactAPIKey(c.APIKey))
+
+}
+
+func redactAPIKey(key string) string {
+
+	if len(key) <= 8 {
+		return "***"
+	}
+
+	return "***" + key[len(key)-4:]
+
+}
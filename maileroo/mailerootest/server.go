@@ -0,0 +1,853 @@
+package mailerootest
+
+import (
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	basePath        = "/api/v2/"
+	maxSubjectLen   = 255
+	maxBulkMessages = 500
+)
+
+var refIDRe = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+
+type Message struct {
+	Endpoint    string
+	ReferenceID string
+	Payload     map[string]any
+	ScheduledAt *time.Time
+	ReceivedAt  time.Time
+}
+
+type Server struct {
+	APIKey string
+
+	srv       *httptest.Server
+	mu        sync.Mutex
+	messages  []Message
+	scheduled map[string]Message
+	refIDs    map[string]bool
+	now       func() time.Time
+}
+
+func NewServer() *Server {
+
+	s := &Server{
+		scheduled: map[string]Message{},
+		refIDs:    map[string]bool{},
+		now:       time.Now,
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(basePath+"emails", s.handleBasic)
+	mux.HandleFunc(basePath+"emails/template", s.handleTemplate)
+	mux.HandleFunc(basePath+"emails/bulk", s.handleBulk)
+	mux.HandleFunc(basePath+"emails/scheduled", s.handleScheduledList)
+	mux.HandleFunc(basePath+"emails/scheduled/", s.handleScheduledDelete)
+
+	s.srv = httptest.NewServer(s.authenticate(mux))
+
+	return s
+
+}
+
+func (s *Server) URL() string {
+	return s.srv.URL + basePath
+}
+
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+func (s *Server) Messages() []Message {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+
+}
+
+func (s *Server) Scheduled() []Message {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Message, 0, len(s.scheduled))
+
+	for _, m := range s.scheduled {
+		out = append(out, m)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ScheduledAt.Before(*out[j].ScheduledAt)
+	})
+
+	return out
+
+}
+
+func (s *Server) Reset() {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = nil
+	s.scheduled = map[string]Message{}
+	s.refIDs = map[string]bool{}
+
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		if !ok || strings.TrimSpace(token) == "" || (s.APIKey != "" && token != s.APIKey) {
+			writeError(w, http.StatusUnauthorized, "Invalid or missing API key.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+
+	})
+}
+
+func (s *Server) handleBasic(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method == http.MethodGet {
+		s.handleEmailList(w, r)
+		return
+	}
+
+	body, ok := decodeBody(w, r)
+
+	if !ok {
+		return
+	}
+
+	if err := validateEnvelope(body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if isEmpty(body["html"]) && isEmpty(body["plain"]) {
+		writeError(w, http.StatusBadRequest, "Either html or plain body is required.")
+		return
+	}
+
+	s.accept(w, "emails", body)
+
+}
+
+func (s *Server) handleTemplate(w http.ResponseWriter, r *http.Request) {
+
+	body, ok := decodeBody(w, r)
+
+	if !ok {
+		return
+	}
+
+	if err := validateEnvelope(body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if id, ok := body["template_id"].(float64); !ok || id < 1 {
+		writeError(w, http.StatusBadRequest, "The template_id field is required.")
+		return
+	}
+
+	s.accept(w, "emails/template", body)
+
+}
+
+func (s *Server) handleBulk(w http.ResponseWriter, r *http.Request) {
+
+	body, ok := decodeBody(w, r)
+
+	if !ok {
+		return
+	}
+
+	if err := validateSubject(body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hasBody := !isEmpty(body["html"]) || !isEmpty(body["plain"])
+	_, hasTemplate := body["template_id"]
+
+	if hasBody == hasTemplate {
+		writeError(w, http.StatusBadRequest, "Provide either html/plain or template_id.")
+		return
+	}
+
+	msgs, _ := body["messages"].([]any)
+
+	if len(msgs) == 0 || len(msgs) > maxBulkMessages {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("The messages field must contain between 1 and %d items.", maxBulkMessages))
+		return
+	}
+
+	shared := map[string]any{}
+
+	for k, v := range body {
+
+		if k != "messages" {
+			shared[k] = v
+		}
+
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := make([]map[string]any, 0, len(msgs))
+	seen := map[string]bool{}
+
+	for i, raw := range msgs {
+
+		m, ok := raw.(map[string]any)
+
+		if !ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("messages.%d must be an object.", i))
+			return
+		}
+
+		if err := validateAddresses(m); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("messages.%d: %s", i, err.Error()))
+			return
+		}
+
+		if _, err := s.scheduledAt(m); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("messages.%d: %s", i, err.Error()))
+			return
+		}
+
+		refID, err := s.referenceID(m)
+
+		if err == nil && seen[refID] {
+			err = fmt.Errorf("The reference_id %s is already in use.", refID)
+		}
+
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("messages.%d: %s", i, err.Error()))
+			return
+		}
+
+		seen[refID] = true
+		m["reference_id"] = refID
+		items = append(items, m)
+
+	}
+
+	ids := make([]string, 0, len(items))
+
+	for _, m := range items {
+
+		payload := map[string]any{}
+
+		for k, v := range shared {
+			payload[k] = v
+		}
+
+		for k, v := range m {
+			payload[k] = v
+		}
+
+		refID := m["reference_id"].(string)
+		scheduledAt, _ := s.scheduledAt(payload)
+
+		msg := Message{
+			Endpoint:    "emails/bulk",
+			ReferenceID: refID,
+			Payload:     payload,
+			ScheduledAt: scheduledAt,
+			ReceivedAt:  s.now(),
+		}
+
+		if scheduledAt != nil {
+			s.scheduled[refID] = msg
+		}
+
+		s.refIDs[refID] = true
+		s.messages = append(s.messages, msg)
+
+		ids = append(ids, refID)
+
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success": true,
+		"message": "The emails have been queued for delivery.",
+		"data":    map[string]any{"reference_ids": ids},
+	})
+
+}
+
+func (s *Server) scheduledAt(body map[string]any) (*time.Time, error) {
+
+	raw, ok := body["scheduled_at"].(string)
+
+	if !ok {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, raw)
+
+	if err != nil {
+		return nil, errors.New("The scheduled_at field must be a valid RFC 3339 date.")
+	}
+
+	if t.Before(s.now()) {
+		return nil, errors.New("The scheduled_at field must be a date in the future.")
+	}
+
+	return &t, nil
+
+}
+
+func (s *Server) accept(w http.ResponseWriter, endpoint string, body map[string]any) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	refID, err := s.referenceID(body)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	msg := Message{
+		Endpoint:    endpoint,
+		ReferenceID: refID,
+		Payload:     body,
+		ReceivedAt:  s.now(),
+	}
+
+	scheduledAt, err := s.scheduledAt(body)
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if scheduledAt != nil {
+		msg.ScheduledAt = scheduledAt
+		s.scheduled[refID] = msg
+	}
+
+	s.refIDs[refID] = true
+	s.messages = append(s.messages, msg)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success": true,
+		"message": "The email has been queued for delivery.",
+		"data":    map[string]any{"reference_id": refID},
+	})
+
+}
+
+func (s *Server) handleScheduledList(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+	if err != nil || perPage < 1 || perPage > 100 {
+		writeError(w, http.StatusBadRequest, "The per_page field must be between 1 and 100.")
+		return
+	}
+
+	all, err := filterScheduled(s.Scheduled(), r.URL.Query())
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch q := r.URL.Query(); q.Get("sort_by") {
+
+	case "", "scheduled_at":
+
+		if q.Get("sort_order") == "desc" {
+			sort.SliceStable(all, func(i, j int) bool { return all[i].ScheduledAt.After(*all[j].ScheduledAt) })
+		}
+
+	case "created_at":
+
+		desc := q.Get("sort_order") == "desc"
+
+		sort.SliceStable(all, func(i, j int) bool {
+
+			if desc {
+				return all[i].ReceivedAt.After(all[j].ReceivedAt)
+			}
+
+			return all[i].ReceivedAt.Before(all[j].ReceivedAt)
+
+		})
+
+	default:
+		writeError(w, http.StatusBadRequest, "The sort_by field must be scheduled_at or created_at.")
+		return
+
+	}
+
+	totalPages := (len(all) + perPage - 1) / perPage
+	results := []map[string]any{}
+
+	for i := (page - 1) * perPage; i < len(all) && i < page*perPage; i++ {
+
+		m := all[i]
+
+		results = append(results, map[string]any{
+			"reference_id": m.ReferenceID,
+			"subject":      m.Payload["subject"],
+			"template_id":  m.Payload["template_id"],
+			"tags":         m.Payload["tags"],
+			"scheduled_at": m.ScheduledAt.UTC().Format(time.RFC3339),
+			"created_at":   m.ReceivedAt.UTC().Format(time.RFC3339),
+		})
+
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success": true,
+		"message": "OK",
+		"data": map[string]any{
+			"page":        page,
+			"per_page":    perPage,
+			"total_count": len(all),
+			"total_pages": totalPages,
+			"results":     results,
+		},
+	})
+
+}
+
+func filterScheduled(all []Message, q url.Values) ([]Message, error) {
+
+	var start, end time.Time
+
+	for name, t := range map[string]*time.Time{"start_date": &start, "end_date": &end} {
+
+		if raw := q.Get(name); raw != "" {
+
+			parsed, err := time.Parse(time.RFC3339, raw)
+
+			if err != nil {
+				return nil, fmt.Errorf("The %s field must be a valid RFC 3339 date.", name)
+			}
+
+			*t = parsed
+
+		}
+
+	}
+
+	out := all[:0]
+
+	for _, m := range all {
+
+		if !start.IsZero() && m.ScheduledAt.Before(start) {
+			continue
+		}
+
+		if !end.IsZero() && !m.ScheduledAt.Before(end) {
+			continue
+		}
+
+		if id := q.Get("template_id"); id != "" && fmt.Sprint(m.Payload["template_id"]) != id {
+			continue
+		}
+
+		tags, _ := m.Payload["tags"].(map[string]any)
+		matches := true
+
+		for _, tag := range q["tag"] {
+
+			key, value, _ := strings.Cut(tag, ":")
+
+			if fmt.Sprint(tags[key]) != value {
+				matches = false
+				break
+			}
+
+		}
+
+		if matches {
+			out = append(out, m)
+		}
+
+	}
+
+	return out, nil
+
+}
+
+func (s *Server) handleScheduledDelete(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+		return
+	}
+
+	refID := strings.TrimPrefix(r.URL.Path, basePath+"emails/scheduled/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.scheduled[refID]; !ok {
+		writeError(w, http.StatusNotFound, "The scheduled email could not be found.")
+		return
+	}
+
+	delete(s.scheduled, refID)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success": true,
+		"message": "The scheduled email has been cancelled.",
+	})
+
+}
+
+func sentEmailData(m Message) map[string]any {
+
+	data := map[string]any{}
+
+	for k, v := range m.Payload {
+
+		if k != "attachments" {
+			data[k] = v
+		}
+
+	}
+
+	atts, _ := m.Payload["attachments"].([]any)
+	infos := make([]map[string]any, 0, len(atts))
+
+	for _, raw := range atts {
+
+		a, _ := raw.(map[string]any)
+		content, _ := a["content"].(string)
+
+		infos = append(infos, map[string]any{
+			"file_name":    a["file_name"],
+			"content_type": a["content_type"],
+			"inline":       a["inline"],
+			"size":         len(content) / 4 * 3,
+		})
+
+	}
+
+	status := "sent"
+
+	if m.ScheduledAt != nil {
+		status = "scheduled"
+	}
+
+	data["reference_id"] = m.ReferenceID
+	data["attachments"] = infos
+	data["status"] = status
+	data["created_at"] = m.ReceivedAt.UTC().Format(time.RFC3339)
+
+	return data
+
+}
+
+func (s *Server) handleEmailList(w http.ResponseWriter, r *http.Request) {
+
+	q := r.URL.Query()
+
+	page, err := strconv.Atoi(q.Get("page"))
+
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(q.Get("per_page"))
+
+	if err != nil || perPage < 1 || perPage > 100 {
+		writeError(w, http.StatusBadRequest, "The per_page field must be between 1 and 100.")
+		return
+	}
+
+	var start, end time.Time
+
+	for name, t := range map[string]*time.Time{"start_date": &start, "end_date": &end} {
+
+		if raw := q.Get(name); raw != "" {
+
+			parsed, err := time.Parse(time.RFC3339, raw)
+
+			if err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("The %s field must be a valid RFC 3339 date.", name))
+				return
+			}
+
+			*t = parsed
+
+		}
+
+	}
+
+	var all []map[string]any
+
+	for _, m := range s.Messages() {
+
+		if !start.IsZero() && m.ReceivedAt.Before(start) {
+			continue
+		}
+
+		if !end.IsZero() && !m.ReceivedAt.Before(end) {
+			continue
+		}
+
+		data := sentEmailData(m)
+
+		if status := q.Get("status"); status != "" && data["status"] != status {
+			continue
+		}
+
+		if rcpt := q.Get("recipient"); rcpt != "" && !hasRecipient(m.Payload, rcpt) {
+			continue
+		}
+
+		if domain := q.Get("domain"); domain != "" {
+
+			from, _ := m.Payload["from"].(map[string]any)
+			addr, _ := from["address"].(string)
+
+			if !strings.HasSuffix(strings.ToLower(addr), "@"+domain) {
+				continue
+			}
+
+		}
+
+		tags, _ := m.Payload["tags"].(map[string]any)
+		matches := true
+
+		for _, tag := range q["tag"] {
+
+			key, value, _ := strings.Cut(tag, ":")
+
+			if fmt.Sprint(tags[key]) != value {
+				matches = false
+				break
+			}
+
+		}
+
+		if matches {
+			all = append(all, data)
+		}
+
+	}
+
+	totalPages := (len(all) + perPage - 1) / perPage
+	results := []map[string]any{}
+
+	for i := (page - 1) * perPage; i < len(all) && i < page*perPage; i++ {
+		results = append(results, all[i])
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"success": true,
+		"message": "OK",
+		"data": map[string]any{
+			"page":        page,
+			"per_page":    perPage,
+			"total_count": len(all),
+			"total_pages": totalPages,
+			"results":     results,
+		},
+	})
+
+}
+
+func hasRecipient(payload map[string]any, address string) bool {
+
+	for _, field := range []string{"to", "cc", "bcc"} {
+
+		list, _ := payload[field].([]any)
+
+		for _, raw := range list {
+
+			a, _ := raw.(map[string]any)
+
+			if addr, _ := a["address"].(string); strings.EqualFold(addr, address) {
+				return true
+			}
+
+		}
+
+	}
+
+	return false
+
+}
+
+func (s *Server) referenceID(body map[string]any) (string, error) {
+
+	raw, ok := body["reference_id"]
+
+	if !ok {
+		b := make([]byte, 12)
+		_, _ = rand.Read(b)
+		return hex.EncodeToString(b), nil
+	}
+
+	id, ok := raw.(string)
+
+	if !ok || !refIDRe.MatchString(id) {
+		return "", errors.New("The reference_id must be a 24-character hexadecimal string.")
+	}
+
+	if s.refIDs[id] {
+		return "", fmt.Errorf("The reference_id %s is already in use.", id)
+	}
+
+	return id, nil
+
+}
+
+func validateEnvelope(body map[string]any) error {
+
+	if err := validateSubject(body); err != nil {
+		return err
+	}
+
+	return validateAddresses(body)
+
+}
+
+func validateSubject(body map[string]any) error {
+
+	subject, _ := body["subject"].(string)
+
+	if strings.TrimSpace(subject) == "" || utf8.RuneCountInString(subject) > maxSubjectLen {
+		return fmt.Errorf("The subject field is required and may not be greater than %d characters.", maxSubjectLen)
+	}
+
+	return nil
+
+}
+
+func validateAddresses(body map[string]any) error {
+
+	from, _ := body["from"].(map[string]any)
+
+	if addr, _ := from["address"].(string); !strings.Contains(addr, "@") {
+		return errors.New("The from.address field must be a valid email address.")
+	}
+
+	to, _ := body["to"].([]any)
+
+	if len(to) == 0 {
+		return errors.New("The to field is required.")
+	}
+
+	for _, field := range []string{"to", "cc", "bcc", "reply_to"} {
+
+		list, _ := body[field].([]any)
+
+		for i, raw := range list {
+
+			entry, _ := raw.(map[string]any)
+
+			if addr, _ := entry["address"].(string); !strings.Contains(addr, "@") {
+				return fmt.Errorf("The %s.%d.address field must be a valid email address.", field, i)
+			}
+
+		}
+
+	}
+
+	return nil
+
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed.")
+		return nil, false
+	}
+
+	var reader io.Reader = r.Body
+
+	if r.Header.Get("Content-Encoding") == "gzip" {
+
+		gz, err := gzip.NewReader(r.Body)
+
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid gzip body.")
+			return nil, false
+		}
+
+		defer gz.Close()
+		reader = gz
+
+	}
+
+	var body map[string]any
+
+	if err := json.NewDecoder(reader).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "The request body must be valid JSON.")
+		return nil, false
+	}
+
+	return body, true
+
+}
+
+func isEmpty(v any) bool {
+
+	s, ok := v.(string)
+
+	return !ok || strings.TrimSpace(s) == ""
+
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{"success": false, "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+
+}
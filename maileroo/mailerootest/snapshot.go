@@ -0,0 +1,302 @@
+package mailerootest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/maileroo/maileroo-go-sdk/maileroo"
+	"golang.org/x/net/html"
+)
+
+const UpdateSnapshotsEnv = "MAILEROO_UPDATE_SNAPSHOTS"
+
+func Snapshot(data maileroo.BasicEmailData) (string, error) {
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\n", data.From)
+	writeAddressList(&b, "To", data.To)
+	writeAddressList(&b, "Cc", data.Cc)
+	writeAddressList(&b, "Bcc", data.Bcc)
+	writeAddressList(&b, "Reply-To", data.ReplyTo)
+	fmt.Fprintf(&b, "Subject: %s\n", data.Subject)
+
+	if data.Tracking != nil {
+		fmt.Fprintf(&b, "Tracking: %t\n", *data.Tracking)
+	}
+
+	if t := data.TrackingSettings; t != nil {
+
+		if t.Opens != nil {
+			fmt.Fprintf(&b, "Tracking-Opens: %t\n", *t.Opens)
+		}
+
+		if t.Clicks != nil {
+			fmt.Fprintf(&b, "Tracking-Clicks: %t\n", *t.Clicks)
+		}
+
+		if t.UTMSource != "" || t.UTMMedium != "" || t.UTMCampaign != "" {
+			fmt.Fprintf(&b, "UTM: source=%s medium=%s campaign=%s\n", t.UTMSource, t.UTMMedium, t.UTMCampaign)
+		}
+
+	}
+
+	if data.ScheduledAt != nil {
+		fmt.Fprintf(&b, "Scheduled-At: %s\n", data.ScheduledAt.UTC().Format("2006-01-02T15:04:05Z07:00"))
+	}
+
+	writeAssocMap(&b, "Header", data.Headers)
+	writeAssocMap(&b, "Tag", data.Tags)
+
+	for _, att := range data.Attachments {
+
+		att, err := att.Load()
+
+		if err != nil {
+			return "", fmt.Errorf("attachment %q: %w", att.FileName, err)
+		}
+
+		content, err := base64.StdEncoding.DecodeString(att.Content)
+
+		if err != nil {
+			return "", fmt.Errorf("attachment %q: invalid base64 content: %w", att.FileName, err)
+		}
+
+		sum := sha256.Sum256(content)
+
+		disposition := "attachment"
+
+		if att.Inline {
+			disposition = "inline"
+		}
+
+		fmt.Fprintf(&b, "Attachment: %s; %s; %s; %d bytes; sha256:%s\n", att.FileName, att.ContentType, disposition, len(content), hex.EncodeToString(sum[:]))
+
+	}
+
+	if data.Plain != nil {
+		b.WriteString("\n--- plain ---\n")
+		b.WriteString(normalizeNewlines(*data.Plain))
+		b.WriteString("\n")
+	}
+
+	if data.HTML != nil {
+
+		formatted, err := formatHTML(*data.HTML)
+
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString("\n--- html ---\n")
+		b.WriteString(formatted)
+
+	}
+
+	if data.AMPHTML != nil {
+
+		formatted, err := formatHTML(*data.AMPHTML)
+
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString("\n--- amp html ---\n")
+		b.WriteString(formatted)
+
+	}
+
+	return b.String(), nil
+
+}
+
+func MatchSnapshot(t testing.TB, path string, data maileroo.BasicEmailData) {
+
+	t.Helper()
+
+	got, err := Snapshot(data)
+
+	if err != nil {
+		t.Fatalf("failed to render snapshot: %v", err)
+	}
+
+	if os.Getenv(UpdateSnapshotsEnv) != "" {
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create snapshot directory: %v", err)
+		}
+
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("failed to write snapshot %s: %v", path, err)
+		}
+
+		return
+
+	}
+
+	want, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("failed to read snapshot %s (set %s=1 to create it): %v", path, UpdateSnapshotsEnv, err)
+	}
+
+	if string(want) != got {
+		t.Fatalf("email does not match snapshot %s (set %s=1 to update it)\n--- want\n%s\n--- got\n%s", path, UpdateSnapshotsEnv, want, got)
+	}
+
+}
+
+func writeAddressList(b *strings.Builder, name string, addrs []maileroo.EmailAddress) {
+
+	if len(addrs) == 0 {
+		return
+	}
+
+	parts := make([]string, len(addrs))
+
+	for i, a := range addrs {
+		parts[i] = a.String()
+	}
+
+	fmt.Fprintf(b, "%s: %s\n", name, strings.Join(parts, ", "))
+
+}
+
+func writeAssocMap(b *strings.Builder, prefix string, m maileroo.AssocMap) {
+
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+
+		a, b := strings.ToLower(keys[i]), strings.ToLower(keys[j])
+
+		if a == b {
+			return keys[i] < keys[j]
+		}
+
+		return a < b
+
+	})
+
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s: %s: %v\n", prefix, k, m[k])
+	}
+
+}
+
+func normalizeNewlines(s string) string {
+
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+
+	return strings.TrimRight(s, "\n")
+
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+func formatHTML(src string) (string, error) {
+
+	var out bytes.Buffer
+
+	z := html.NewTokenizer(strings.NewReader(src))
+	depth := 0
+
+	line := func(s string) {
+		out.WriteString(strings.Repeat("  ", depth))
+		out.WriteString(s)
+		out.WriteByte('\n')
+	}
+
+	for {
+
+		tt := z.Next()
+
+		switch tt {
+
+		case html.ErrorToken:
+
+			if err := z.Err(); err != io.EOF {
+				return "", fmt.Errorf("failed to format html: %w", err)
+			}
+
+			return out.String(), nil
+
+		case html.TextToken:
+
+			if text := strings.Join(strings.Fields(string(z.Text())), " "); text != "" {
+				line(html.EscapeString(text))
+			}
+
+		case html.StartTagToken:
+
+			tok := z.Token()
+			line(renderStartTag(tok))
+
+			if !voidElements[tok.Data] {
+				depth++
+			}
+
+		case html.SelfClosingTagToken:
+			line(renderStartTag(z.Token()))
+
+		case html.EndTagToken:
+
+			tok := z.Token()
+
+			if voidElements[tok.Data] {
+				continue
+			}
+
+			depth = max(depth-1, 0)
+			line("</" + tok.Data + ">")
+
+		case html.CommentToken:
+			line("<!--" + string(z.Text()) + "-->")
+
+		case html.DoctypeToken:
+			line("<!DOCTYPE " + string(z.Text()) + ">")
+
+		}
+
+	}
+
+}
+
+func renderStartTag(tok html.Token) string {
+
+	attrs := append([]html.Attribute(nil), tok.Attr...)
+
+	sort.SliceStable(attrs, func(i, j int) bool {
+		return attrs[i].Key < attrs[j].Key
+	})
+
+	var b strings.Builder
+
+	b.WriteString("<" + tok.Data)
+
+	for _, a := range attrs {
+		fmt.Fprintf(&b, " %s=\"%s\"", a.Key, html.EscapeString(a.Val))
+	}
+
+	b.WriteString(">")
+
+	return b.String()
+
+}
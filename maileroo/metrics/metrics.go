@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/maileroo/maileroo-go-sdk/maileroo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type Collector struct {
+	requests  *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	failovers *prometheus.CounterVec
+	bulkSizes prometheus.Histogram
+}
+
+func NewCollector(namespace string) *Collector {
+
+	if namespace == "" {
+		namespace = "maileroo"
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Total number of Maileroo API requests by endpoint, method and status code.",
+		}, []string{"endpoint", "method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of Maileroo API requests by endpoint and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint", "method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_errors_total",
+			Help:      "Total number of failed Maileroo API requests by endpoint and error class.",
+		}, []string{"endpoint", "class"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_retries_total",
+			Help:      "Total number of retried Maileroo API requests by endpoint.",
+		}, []string{"endpoint"}),
+		failovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_failovers_total",
+			Help:      "Total number of Maileroo API requests sent to a failover base URL after a connection error, by endpoint.",
+		}, []string{"endpoint"}),
+		bulkSizes: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "bulk_batch_size",
+			Help:      "Number of messages per bulk send request.",
+			Buckets:   []float64{1, 10, 25, 50, 100, 250, 500},
+		}),
+	}
+
+}
+
+func (c *Collector) Option() maileroo.ClientOption {
+	return maileroo.WithObserver(c)
+}
+
+func (c *Collector) ObserveRequest(ev maileroo.RequestEvent) {
+
+	code := "none"
+
+	if ev.StatusCode > 0 {
+		code = strconv.Itoa(ev.StatusCode)
+	}
+
+	c.requests.WithLabelValues(ev.Endpoint, ev.Method, code).Inc()
+	c.latency.WithLabelValues(ev.Endpoint, ev.Method).Observe(ev.Duration.Seconds())
+
+	if ev.ErrorClass != maileroo.ErrorClassNone {
+		c.errors.WithLabelValues(ev.Endpoint, string(ev.ErrorClass)).Inc()
+	}
+
+	if ev.Attempt > 1 && !ev.Failover {
+		c.retries.WithLabelValues(ev.Endpoint).Inc()
+	}
+
+	if ev.Failover {
+		c.failovers.WithLabelValues(ev.Endpoint).Inc()
+	}
+
+}
+
+func (c *Collector) ObserveBulkBatch(size int) {
+	c.bulkSizes.Observe(float64(size))
+}
+
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+
+	c.requests.Describe(ch)
+	c.latency.Describe(ch)
+	c.errors.Describe(ch)
+	c.retries.Describe(ch)
+	c.failovers.Describe(ch)
+	c.bulkSizes.Describe(ch)
+
+}
+
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+
+	c.requests.Collect(ch)
+	c.latency.Collect(ch)
+	c.errors.Collect(ch)
+	c.retries.Collect(ch)
+	c.failovers.Collect(ch)
+	c.bulkSizes.Collect(ch)
+
+}
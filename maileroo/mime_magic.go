@@ -0,0 +1,124 @@
+package maileroo
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+)
+
+type magicSignature struct {
+	offset int
+	prefix []byte
+	mime   string
+}
+
+var magicSignatures = []magicSignature{
+	{0, []byte("%PDF-"), "application/pdf"},
+	{0, []byte("ID3"), "audio/mpeg"},
+	{0, []byte{0xFF, 0xFB}, "audio/mpeg"},
+	{0, []byte{0xFF, 0xF3}, "audio/mpeg"},
+	{0, []byte{0xFF, 0xF2}, "audio/mpeg"},
+	{0, []byte("fLaC"), "audio/flac"},
+	{0, []byte("II*\x00"), "image/tiff"},
+	{0, []byte("MM\x00*"), "image/tiff"},
+	{0, []byte("7z\xBC\xAF\x27\x1C"), "application/x-7z-compressed"},
+	{0, []byte("Rar!\x1A\x07"), "application/vnd.rar"},
+	{4, []byte("ftypM4A "), "audio/mp4"},
+	{4, []byte("ftypqt  "), "video/quicktime"},
+	{4, []byte("ftypM4V "), "video/x-m4v"},
+}
+
+var rtfMagic = []byte("{\\rtf")
+
+func detectMimeFromMagic(buf []byte) string {
+
+	for _, sig := range magicSignatures {
+
+		if len(buf) >= sig.offset+len(sig.prefix) && bytes.Equal(buf[sig.offset:sig.offset+len(sig.prefix)], sig.prefix) {
+			return sig.mime
+		}
+
+	}
+
+	return ""
+
+}
+
+func detectMimeFromZip(buf []byte) string {
+
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+
+	if err != nil {
+		return "application/zip"
+	}
+
+	hasContentTypes := false
+	prefixes := map[string]bool{}
+
+	for _, f := range zr.File {
+
+		if f.Name == "mimetype" {
+
+			if mt := readZipMimetype(f); mt != "" {
+				return mt
+			}
+
+		}
+
+		if f.Name == "[Content_Types].xml" {
+			hasContentTypes = true
+		}
+
+		if i := strings.IndexByte(f.Name, '/'); i > 0 {
+			prefixes[f.Name[:i]] = true
+		}
+
+	}
+
+	if hasContentTypes {
+
+		switch {
+
+		case prefixes["word"]:
+			return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+		case prefixes["xl"]:
+			return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+		case prefixes["ppt"]:
+			return "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+
+		}
+
+	}
+
+	return "application/zip"
+
+}
+
+func readZipMimetype(f *zip.File) string {
+
+	rc, err := f.Open()
+
+	if err != nil {
+		return ""
+	}
+
+	defer rc.Close()
+
+	b, err := io.ReadAll(io.LimitReader(rc, 128))
+
+	if err != nil {
+		return ""
+	}
+
+	mt := strings.TrimSpace(string(b))
+
+	if !mediaTypeRe.MatchString(mt) {
+		return ""
+	}
+
+	return mt
+
+}
@@ -0,0 +1,225 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var ErrAllRecipientsDeduplicated = errors.New("every message was dropped as a duplicate recipient; nothing was sent")
+
+type NormalizationOptions struct {
+	LowercaseDomain bool
+	StripGmailDots  bool
+	StripGmailPlus  bool
+	Deduplicate     bool
+	OnReport        func(NormalizationReport)
+}
+
+type NormalizationChange struct {
+	Field      string
+	Original   string
+	Normalized string
+	Removed    bool
+}
+
+type NormalizationReport struct {
+	Changes []NormalizationChange
+}
+
+func WithRecipientNormalization(opts NormalizationOptions) ClientOption {
+	return func(c *Client) error {
+		o := opts
+		c.normalization = &o
+		return nil
+	}
+}
+
+var gmailDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+}
+
+func NormalizeAddress(addr string, opts NormalizationOptions) string {
+
+	addr = strings.TrimSpace(addr)
+
+	at := strings.LastIndexByte(addr, '@')
+
+	if at <= 0 {
+		return addr
+	}
+
+	local, domain := addr[:at], addr[at+1:]
+
+	if opts.LowercaseDomain {
+		domain = strings.ToLower(domain)
+	}
+
+	if gmailDomains[strings.ToLower(domain)] {
+
+		if opts.StripGmailPlus {
+
+			if i := strings.IndexByte(local, '+'); i > 0 {
+				local = local[:i]
+			}
+
+		}
+
+		if opts.StripGmailDots {
+			local = strings.ReplaceAll(local, ".", "")
+		}
+
+	}
+
+	return local + "@" + domain
+
+}
+
+type recipientNormalizer struct {
+	opts   NormalizationOptions
+	seen   map[string]bool
+	report NormalizationReport
+}
+
+func newRecipientNormalizer(opts NormalizationOptions) *recipientNormalizer {
+	return &recipientNormalizer{opts: opts, seen: map[string]bool{}}
+}
+
+func (n *recipientNormalizer) list(field string, addrs []EmailAddress, dedupe bool) []EmailAddress {
+
+	if addrs == nil {
+		return nil
+	}
+
+	out := make([]EmailAddress, 0, len(addrs))
+
+	for i, a := range addrs {
+
+		path := fmt.Sprintf("%s[%d]", field, i)
+		normalized := NormalizeAddress(a.Address, n.opts)
+
+		if normalized != a.Address {
+			n.report.Changes = append(n.report.Changes, NormalizationChange{Field: path, Original: a.Address, Normalized: normalized})
+		}
+
+		if dedupe && n.opts.Deduplicate {
+
+			key := strings.ToLower(normalized)
+
+			if n.seen[key] {
+				n.report.Changes = append(n.report.Changes, NormalizationChange{Field: path, Original: a.Address, Normalized: normalized, Removed: true})
+				continue
+			}
+
+			n.seen[key] = true
+
+		}
+
+		a.Address = normalized
+		out = append(out, a)
+
+	}
+
+	return out
+
+}
+
+func (n *recipientNormalizer) payload(p *BasePayload) {
+
+	p.To = n.list("to", p.To, true)
+	p.Cc = n.list("cc", p.Cc, true)
+	p.Bcc = n.list("bcc", p.Bcc, true)
+	p.ReplyTo = n.list("reply_to", p.ReplyTo, false)
+
+}
+
+func (n *recipientNormalizer) bulkMessages(in []BulkMessage) ([]BulkMessage, []int) {
+
+	out := make([]BulkMessage, 0, len(in))
+	kept := make([]int, 0, len(in))
+
+	for i, m := range in {
+
+		prefix := fmt.Sprintf("messages[%d].", i)
+
+		if n.opts.Deduplicate && len(m.To) > 0 && !n.anyUnseen(m.To) {
+			n.list(prefix+"to", m.To, true)
+			n.report.Changes = append(n.report.Changes, NormalizationChange{Field: fmt.Sprintf("messages[%d]", i), Removed: true})
+			continue
+		}
+
+		m.To = n.list(prefix+"to", m.To, true)
+		m.Cc = n.list(prefix+"cc", m.Cc, true)
+		m.Bcc = n.list(prefix+"bcc", m.Bcc, true)
+		m.ReplyTo = n.list(prefix+"reply_to", m.ReplyTo, false)
+
+		out = append(out, m)
+		kept = append(kept, i)
+
+	}
+
+	return out, kept
+
+}
+
+func (n *recipientNormalizer) anyUnseen(addrs []EmailAddress) bool {
+
+	for _, a := range addrs {
+
+		if !n.seen[strings.ToLower(NormalizeAddress(a.Address, n.opts))] {
+			return true
+		}
+
+	}
+
+	return false
+
+}
+
+type bulkRecipientsKey struct{}
+
+type bulkRecipients struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func (c *Client) withBulkRecipients(ctx context.Context) context.Context {
+
+	if c.normalization == nil || !c.normalization.Deduplicate {
+		return ctx
+	}
+
+	return context.WithValue(ctx, bulkRecipientsKey{}, &bulkRecipients{seen: map[string]bool{}})
+
+}
+
+func (c *Client) normalizeBulkRecipients(ctx context.Context, messages []BulkMessage) ([]BulkMessage, []int) {
+
+	n := newRecipientNormalizer(*c.normalization)
+
+	if shared, ok := ctx.Value(bulkRecipientsKey{}).(*bulkRecipients); ok {
+		shared.mu.Lock()
+		n.seen = shared.seen
+		messages, kept := n.bulkMessages(messages)
+		shared.mu.Unlock()
+		n.flush(ctx)
+		return messages, kept
+	}
+
+	messages, kept := n.bulkMessages(messages)
+	n.flush(ctx)
+
+	return messages, kept
+
+}
+
+func (n *recipientNormalizer) flush(ctx context.Context) {
+
+	if n.opts.OnReport != nil && len(n.report.Changes) > 0 && !isEstimating(ctx) {
+		n.opts.OnReport(n.report)
+	}
+
+}
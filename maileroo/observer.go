@@ -0,0 +1,118 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type ErrorClass string
+
+const (
+	ErrorClassNone     ErrorClass = ""
+	ErrorClassNetwork  ErrorClass = "network"
+	ErrorClassTimeout  ErrorClass = "timeout"
+	ErrorClassCanceled ErrorClass = "canceled"
+	ErrorClassHTTP     ErrorClass = "http"
+	ErrorClassDecode   ErrorClass = "decode"
+	ErrorClassAPI      ErrorClass = "api"
+)
+
+type RequestEvent struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	Duration   time.Duration
+	Attempt    int
+	Failover   bool
+	ErrorClass ErrorClass
+	Tags       map[string]string
+}
+
+type Observer interface {
+	ObserveRequest(ev RequestEvent)
+	ObserveBulkBatch(size int)
+}
+
+func WithObserver(o Observer) ClientOption {
+	return func(c *Client) error {
+		if o == nil {
+			return errors.New("observer must not be nil")
+		}
+		c.observers = append(c.observers, o)
+		return nil
+	}
+}
+
+func (c *Client) observeRequest(ev RequestEvent) {
+
+	for _, o := range c.observers {
+		o.ObserveRequest(ev)
+	}
+
+}
+
+func (c *Client) observeBulkBatch(size int) {
+
+	for _, o := range c.observers {
+		o.ObserveBulkBatch(size)
+	}
+
+}
+
+var (
+	routeRefIDRe   = regexp.MustCompile(`^[0-9a-fA-F]{24}$`)
+	routeNumericRe = regexp.MustCompile(`^[0-9]+$`)
+)
+
+func routeLabel(baseURL, endpoint string) string {
+
+	path := strings.TrimPrefix(endpoint, baseURL)
+
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, s := range segments {
+
+		switch {
+
+		case routeRefIDRe.MatchString(s):
+			segments[i] = "{reference_id}"
+
+		case routeNumericRe.MatchString(s):
+			segments[i] = "{id}"
+
+		}
+
+	}
+
+	return strings.Join(segments, "/")
+
+}
+
+func classifyTransportError(ctx context.Context, err error) ErrorClass {
+
+	if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		return ErrorClassCanceled
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassTimeout
+	}
+
+	var ne net.Error
+
+	if errors.As(err, &ne) && ne.Timeout() {
+		return ErrorClassTimeout
+	}
+
+	return ErrorClassNetwork
+
+}
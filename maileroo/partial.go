@@ -0,0 +1,41 @@
+package maileroo
+
+import (
+	"fmt"
+)
+
+type PartialResult struct {
+	ReferenceIDs []string
+	Completed    int
+	Total        int
+	Err          error
+}
+
+func (p *PartialResult) Error() string {
+
+	if p.Total > 0 {
+		return fmt.Sprintf("operation stopped after %d of %d steps: %v", p.Completed, p.Total, p.Err)
+	}
+
+	return fmt.Sprintf("operation stopped after %d steps: %v", p.Completed, p.Err)
+
+}
+
+func (p *PartialResult) Unwrap() error {
+	return p.Err
+}
+
+func newPartialResult(ids []string, completed, total int, err error) error {
+
+	if completed == 0 {
+		return err
+	}
+
+	return &PartialResult{
+		ReferenceIDs: ids,
+		Completed:    completed,
+		Total:        total,
+		Err:          err,
+	}
+
+}
@@ -0,0 +1,69 @@
+package maileroo
+
+import (
+	"reflect"
+)
+
+func compactPayload(payload map[string]any) map[string]any {
+
+	out := make(map[string]any, len(payload))
+
+	for k, v := range payload {
+
+		if isOmittable(v) {
+			continue
+		}
+
+		if msgs, ok := v.([]map[string]any); ok {
+
+			compacted := make([]map[string]any, len(msgs))
+
+			for i, m := range msgs {
+				compacted[i] = compactPayload(m)
+			}
+
+			v = compacted
+
+		}
+
+		out[k] = v
+
+	}
+
+	return out
+
+}
+
+func isOmittable(v any) bool {
+
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+
+	}
+
+	return false
+
+}
+
+func (c *Client) emailRequestBody(payload map[string]any) map[string]any {
+
+	payload = compactPayload(payload)
+
+	if c.encodedWords {
+		encodePayloadWords(payload)
+	}
+
+	return payload
+
+}
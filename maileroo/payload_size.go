@@ -0,0 +1,122 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const DefaultMaxRequestSize int64 = 25 << 20
+
+type PayloadEstimate struct {
+	Requests        []int64
+	TotalBytes      int64
+	LargestBytes    int64
+	AttachmentBytes int64
+	Limit           int64
+	WithinLimit     bool
+}
+
+type payloadProbe struct {
+	estimate PayloadEstimate
+}
+
+type payloadProbeKey struct{}
+
+func WithMaxRequestSize(n int64) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return errors.New("max request size must be a positive number of bytes")
+		}
+		c.maxRequestSize = n
+		return nil
+	}
+}
+
+func (c *Client) EstimatePayloadSize(ctx context.Context, data any) (*PayloadEstimate, error) {
+
+	probe := &payloadProbe{}
+	ctx = context.WithValue(ctx, payloadProbeKey{}, probe)
+
+	var err error
+
+	switch d := data.(type) {
+
+	case BasicEmailData:
+		_, err = c.SendBasicEmailResult(ctx, d)
+
+	case *BasicEmailData:
+		_, err = c.SendBasicEmailResult(ctx, *d)
+
+	case TemplatedEmailData:
+		_, err = c.SendTemplatedEmailResult(ctx, d)
+
+	case *TemplatedEmailData:
+		_, err = c.SendTemplatedEmailResult(ctx, *d)
+
+	case BulkEmailData:
+		_, err = c.SendBulkEmailsChunked(ctx, d)
+
+	case *BulkEmailData:
+		_, err = c.SendBulkEmailsChunked(ctx, *d)
+
+	default:
+		return nil, fmt.Errorf("cannot estimate the payload size of %T: expected BasicEmailData, TemplatedEmailData or BulkEmailData", data)
+
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	est := probe.estimate
+
+	est.Limit = c.maxRequestSize
+
+	if est.Limit <= 0 {
+		est.Limit = DefaultMaxRequestSize
+	}
+
+	est.WithinLimit = est.LargestBytes <= est.Limit
+
+	return &est, nil
+
+}
+
+func isEstimating(ctx context.Context) bool {
+
+	_, ok := ctx.Value(payloadProbeKey{}).(*payloadProbe)
+
+	return ok
+
+}
+
+func probePayload(ctx context.Context, payload map[string]any, body any) (bool, error) {
+
+	probe, ok := ctx.Value(payloadProbeKey{}).(*payloadProbe)
+
+	if !ok {
+		return false, nil
+	}
+
+	b, err := encodeRequestBody(body)
+
+	if err != nil {
+		return true, err
+	}
+
+	size := int64(len(b))
+
+	atts, _ := payload["attachments"].([]Attachment)
+
+	for _, att := range atts {
+		probe.estimate.AttachmentBytes += int64(len(att.Content))
+	}
+
+	probe.estimate.Requests = append(probe.estimate.Requests, size)
+	probe.estimate.TotalBytes += size
+	probe.estimate.LargestBytes = max(probe.estimate.LargestBytes, size)
+
+	return true, nil
+
+}
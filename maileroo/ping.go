@@ -0,0 +1,79 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+var ErrUnauthorized = errors.New("the API key was rejected")
+
+type PingResult struct {
+	Authenticated bool
+	StatusCode    int
+	Latency       time.Duration
+	APIVersion    string
+}
+
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+
+	meta := &responseMeta{}
+	ctx = withResponseMeta(ctx, meta)
+
+	var out struct {
+		Success bool           `json:"success"`
+		Message string         `json:"message"`
+		Errors  APIFieldErrors `json:"errors"`
+	}
+
+	start := time.Now()
+	err := c.sendRequest(ctx, http.MethodGet, "emails/scheduled?page=1&per_page=1", nil, &out)
+
+	res := &PingResult{
+		StatusCode: meta.status,
+		Latency:    time.Since(start),
+		APIVersion: c.apiVersion(meta.header),
+	}
+
+	switch {
+
+	case meta.status == http.StatusUnauthorized || meta.status == http.StatusForbidden:
+		return res, ErrUnauthorized
+
+	case err != nil:
+		return res, err
+
+	case !out.Success:
+		return res, newAPIError(out.Message, out.Errors)
+
+	}
+
+	res.Authenticated = true
+
+	return res, nil
+
+}
+
+func (c *Client) apiVersion(header http.Header) string {
+
+	if v := header.Get("X-API-Version"); v != "" {
+		return v
+	}
+
+	u, err := url.Parse(c.apiBaseURL)
+
+	if err != nil {
+		return ""
+	}
+
+	if v := path.Base(strings.TrimRight(u.Path, "/")); len(v) > 1 && v[0] == 'v' {
+		return v
+	}
+
+	return ""
+
+}
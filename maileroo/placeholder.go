@@ -0,0 +1,126 @@
+package maileroo
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*)\s*\}\}`)
+
+func (d *BasicEmailData) applyPlaceholders() error {
+
+	if d.PlaceholderData == nil {
+		return nil
+	}
+
+	subject, err := interpolatePlaceholders("subject", d.Subject, d.PlaceholderData, false)
+
+	if err != nil {
+		return err
+	}
+
+	d.Subject = subject
+
+	if d.HTML != nil {
+
+		html, err := interpolatePlaceholders("html", *d.HTML, d.PlaceholderData, true)
+
+		if err != nil {
+			return err
+		}
+
+		d.HTML = &html
+
+	}
+
+	if d.Plain != nil {
+
+		plain, err := interpolatePlaceholders("plain", *d.Plain, d.PlaceholderData, false)
+
+		if err != nil {
+			return err
+		}
+
+		d.Plain = &plain
+
+	}
+
+	return nil
+
+}
+
+var placeholderFuncs = template.FuncMap{
+	"escapeHTML": func(v any) string {
+		return html.EscapeString(fmt.Sprint(v))
+	},
+}
+
+func interpolatePlaceholders(field, text string, data map[string]any, escapeHTML bool) (string, error) {
+
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(field).Option("missingkey=error").Funcs(placeholderFuncs).Parse(placeholderTemplate(text, escapeHTML))
+
+	if err != nil {
+		return "", &FieldError{Path: field, Code: FieldErrorInvalid, Message: fmt.Sprintf("%s: failed to parse placeholders: %v", field, err), err: err}
+	}
+
+	var b strings.Builder
+
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", &FieldError{Path: field, Code: FieldErrorInvalid, Message: fmt.Sprintf("%s: failed to interpolate placeholders: %v", field, err), err: err}
+	}
+
+	return b.String(), nil
+
+}
+
+// Only {{name}} tokens become template actions. Everything else, including
+// mso comments and text that merely looks like a placeholder, is emitted as a
+// quoted constant so the template parser never interprets it.
+func placeholderTemplate(text string, escapeHTML bool) string {
+
+	var b strings.Builder
+
+	last := 0
+
+	for _, m := range placeholderRe.FindAllStringSubmatchIndex(text, -1) {
+
+		writeTemplateConstant(&b, text[last:m[0]])
+
+		b.WriteString("{{.")
+		b.WriteString(text[m[2]:m[3]])
+
+		if escapeHTML {
+			b.WriteString(" | escapeHTML")
+		}
+
+		b.WriteString("}}")
+
+		last = m[1]
+
+	}
+
+	writeTemplateConstant(&b, text[last:])
+
+	return b.String()
+
+}
+
+func writeTemplateConstant(b *strings.Builder, s string) {
+
+	if s == "" {
+		return
+	}
+
+	b.WriteString("{{")
+	b.WriteString(strconv.Quote(s))
+	b.WriteString("}}")
+
+}
@@ -0,0 +1,78 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+type rawResponseKey struct{}
+type requestHeadersKey struct{}
+type responseMetaKey struct{}
+
+type responseMeta struct {
+	status int
+	header http.Header
+}
+
+func withRequestHeaders(ctx context.Context, h http.Header) context.Context {
+	return context.WithValue(ctx, requestHeadersKey{}, h)
+}
+
+func withResponseMeta(ctx context.Context, meta *responseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+func applyRequestHeaders(ctx context.Context, req *http.Request) {
+
+	if h, ok := ctx.Value(requestHeadersKey{}).(http.Header); ok {
+
+		for k, v := range h {
+			req.Header[k] = append([]string(nil), v...)
+		}
+
+	}
+
+}
+
+func captureResponseMeta(ctx context.Context, resp *http.Response) {
+
+	if meta, ok := ctx.Value(responseMetaKey{}).(*responseMeta); ok && meta != nil {
+		meta.status = resp.StatusCode
+		meta.header = resp.Header.Clone()
+	}
+
+}
+
+func WithRawResponse(ctx context.Context, dst *json.RawMessage) context.Context {
+	return context.WithValue(ctx, rawResponseKey{}, dst)
+}
+
+func captureRawResponse(ctx context.Context, raw []byte) {
+
+	if dst, ok := ctx.Value(rawResponseKey{}).(*json.RawMessage); ok && dst != nil {
+		*dst = append((*dst)[:0], raw...)
+	}
+
+}
+
+func (c *Client) Do(ctx context.Context, method, path string, body any, out any) error {
+
+	if strings.TrimSpace(method) == "" {
+		return errors.New("method must be a non-empty string")
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return errors.New("path must be a non-empty string")
+	}
+
+	if out == nil {
+		var discard json.RawMessage
+		out = &discard
+	}
+
+	return c.sendRequest(ctx, strings.ToUpper(method), path, body, out)
+
+}
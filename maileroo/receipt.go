@@ -0,0 +1,207 @@
+package maileroo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ReceiptTagType = "receipt"
+
+type OrderLine struct {
+	Description string
+	SKU         string
+	Quantity    int
+	UnitPrice   int64
+}
+
+func (l OrderLine) Amount() int64 {
+	return int64(l.Quantity) * l.UnitPrice
+}
+
+type Order struct {
+	ID       string
+	Customer EmailAddress
+	Currency string
+	Lines    []OrderLine
+	Discount int64
+	Tax      int64
+	Shipping int64
+	PlacedAt time.Time
+	Data     map[string]any
+}
+
+func (o Order) Subtotal() int64 {
+
+	var total int64
+
+	for _, l := range o.Lines {
+		total += l.Amount()
+	}
+
+	return total
+
+}
+
+func (o Order) Total() int64 {
+	return o.Subtotal() - o.Discount + o.Tax + o.Shipping
+}
+
+type InvoiceRenderer interface {
+	RenderInvoice(ctx context.Context, order Order) (fileName string, pdf []byte, err error)
+}
+
+type InvoiceRendererFunc func(ctx context.Context, order Order) (string, []byte, error)
+
+func (f InvoiceRendererFunc) RenderInvoice(ctx context.Context, order Order) (string, []byte, error) {
+	return f(ctx, order)
+}
+
+type ReceiptData struct {
+	From       EmailAddress
+	ReplyTo    []EmailAddress
+	Bcc        []EmailAddress
+	Subject    string
+	TemplateID int
+	Order      Order
+	Renderer   InvoiceRenderer
+	Tags       AssocMap
+	Headers    AssocMap
+}
+
+func (c *Client) SendReceipt(ctx context.Context, data ReceiptData) (*SendResult, error) {
+
+	o := data.Order
+
+	if strings.TrimSpace(o.ID) == "" {
+		return nil, newFieldError("order.id", FieldErrorRequired, "order.id is required")
+	}
+
+	if len(o.Lines) == 0 {
+		return nil, newFieldError("order.lines", FieldErrorRequired, "order.lines must have at least one item")
+	}
+
+	for i, l := range o.Lines {
+
+		if l.Quantity < 1 {
+			return nil, newFieldError(fmt.Sprintf("order.lines[%d].quantity", i), FieldErrorInvalid, "order.lines[%d].quantity must be a positive integer", i)
+		}
+
+	}
+
+	if data.TemplateID < 1 {
+		return nil, newFieldError("template_id", FieldErrorRequired, "template_id must be a positive integer")
+	}
+
+	subject := data.Subject
+
+	if subject == "" {
+		subject = "Your receipt for order " + o.ID
+	}
+
+	headers, err := NewHeaderBuilder().
+		AutoSubmitted(AutoSubmittedAutoGenerated).
+		EntityRefID(o.ID).
+		Build()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := mergeAssocMaps(AssocMap{"type": ReceiptTagType, "order_id": o.ID}, data.Tags)
+
+	email := TemplatedEmailData{
+		From:         data.From,
+		To:           []EmailAddress{o.Customer},
+		Bcc:          data.Bcc,
+		ReplyTo:      data.ReplyTo,
+		Subject:      subject,
+		TemplateID:   data.TemplateID,
+		TemplateData: receiptTemplateData(o),
+		Tags:         tags,
+		Headers:      mergeHeaders(headers, data.Headers),
+	}
+
+	if data.Renderer != nil {
+
+		name, pdf, err := data.Renderer.RenderInvoice(ctx, o)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to render invoice for order %s: %w", o.ID, err)
+		}
+
+		if name == "" {
+			name = "invoice-" + o.ID + ".pdf"
+		}
+
+		att, err := AttachmentFromContent(name, pdf, "application/pdf", false)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to attach invoice for order %s: %w", o.ID, err)
+		}
+
+		email.Attachments = []Attachment{*att}
+
+	}
+
+	return c.SendTemplatedEmailResult(ctx, email)
+
+}
+
+func receiptTemplateData(o Order) map[string]any {
+
+	lines := make([]map[string]any, 0, len(o.Lines))
+
+	for _, l := range o.Lines {
+		lines = append(lines, map[string]any{
+			"description": l.Description,
+			"sku":         l.SKU,
+			"quantity":    l.Quantity,
+			"unit_price":  formatMinorUnits(l.UnitPrice),
+			"amount":      formatMinorUnits(l.Amount()),
+		})
+	}
+
+	data := map[string]any{}
+
+	for k, v := range o.Data {
+		data[k] = v
+	}
+
+	data["order_id"] = o.ID
+	data["currency"] = o.Currency
+	data["lines"] = lines
+	data["subtotal"] = formatMinorUnits(o.Subtotal())
+	data["discount"] = formatMinorUnits(o.Discount)
+	data["tax"] = formatMinorUnits(o.Tax)
+	data["shipping"] = formatMinorUnits(o.Shipping)
+	data["total"] = formatMinorUnits(o.Total())
+
+	if !o.PlacedAt.IsZero() {
+		data["placed_at"] = o.PlacedAt.Format(time.RFC3339)
+	}
+
+	return data
+
+}
+
+func formatMinorUnits(amount int64) string {
+
+	sign := ""
+
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	cents := strconv.FormatInt(amount%100, 10)
+
+	if len(cents) < 2 {
+		cents = "0" + cents
+	}
+
+	return sign + strconv.FormatInt(amount/100, 10) + "." + cents
+
+}
@@ -0,0 +1,168 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type RecipientLimits struct {
+	MaxTo  int
+	MaxCc  int
+	MaxBcc int
+}
+
+func WithRecipientLimits(limits RecipientLimits) ClientOption {
+	return func(c *Client) error {
+		if limits.MaxTo < 0 || limits.MaxCc < 0 || limits.MaxBcc < 0 {
+			return errors.New("recipient limits must not be negative")
+		}
+		c.recipientLimits = limits
+		return nil
+	}
+}
+
+func (c *Client) checkRecipientLimits(to, cc, bcc []EmailAddress) error {
+
+	lists := []struct {
+		field string
+		addrs []EmailAddress
+		max   int
+	}{
+		{"to", to, c.recipientLimits.MaxTo},
+		{"cc", cc, c.recipientLimits.MaxCc},
+		{"bcc", bcc, c.recipientLimits.MaxBcc},
+	}
+
+	for _, l := range lists {
+
+		if l.max > 0 && len(l.addrs) > l.max {
+			return newFieldError(l.field, FieldErrorTooMany, "%s has %d recipients, exceeding the maximum of %d", l.field, len(l.addrs), l.max)
+		}
+
+	}
+
+	return nil
+
+}
+
+func (c *Client) SendBasicEmailSplit(ctx context.Context, data BasicEmailData) ([]string, error) {
+
+	parts, err := c.splitRecipients(ctx, data.To, data.Cc, data.Bcc, data.ReferenceID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sendParts(ctx, parts, func(to []EmailAddress, first bool) (string, error) {
+
+		part := data
+		part.To = to
+
+		if !first {
+			part.Cc, part.Bcc = nil, nil
+		}
+
+		return c.SendBasicEmail(ctx, part)
+
+	})
+
+}
+
+func (c *Client) SendTemplatedEmailSplit(ctx context.Context, data TemplatedEmailData) ([]string, error) {
+
+	parts, err := c.splitRecipients(ctx, data.To, data.Cc, data.Bcc, data.ReferenceID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sendParts(ctx, parts, func(to []EmailAddress, first bool) (string, error) {
+
+		part := data
+		part.To = to
+
+		if !first {
+			part.Cc, part.Bcc = nil, nil
+		}
+
+		return c.SendTemplatedEmail(ctx, part)
+
+	})
+
+}
+
+func (c *Client) splitRecipients(ctx context.Context, to, cc, bcc []EmailAddress, referenceID *string) ([][]EmailAddress, error) {
+
+	to, err := c.expandGroups(ctx, to)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(to) == 0 {
+		return [][]EmailAddress{to}, nil
+	}
+
+	size := len(to)
+
+	if c.recipientLimits.MaxTo > 0 {
+		size = min(size, c.recipientLimits.MaxTo)
+	}
+
+	firstSize := size
+
+	if c.maxRecipients > 0 {
+
+		size = min(size, c.maxRecipients)
+		firstSize = min(size, c.maxRecipients-len(cc)-len(bcc))
+
+		if firstSize < 1 {
+			return nil, newFieldError("to", FieldErrorTooMany, "cc and bcc already use %d of the %d recipients allowed per message", len(cc)+len(bcc), c.maxRecipients)
+		}
+
+	}
+
+	parts := [][]EmailAddress{to[:firstSize]}
+
+	for start := firstSize; start < len(to); start += size {
+		parts = append(parts, to[start:min(start+size, len(to))])
+	}
+
+	if len(parts) > 1 && referenceID != nil {
+		return nil, newFieldError("reference_id", FieldErrorConflict, "reference_id cannot be used when the recipients are split into %d messages", len(parts))
+	}
+
+	return parts, nil
+
+}
+
+func sendParts(ctx context.Context, parts [][]EmailAddress, send func(to []EmailAddress, first bool) (string, error)) ([]string, error) {
+
+	ids := make([]string, 0, len(parts))
+
+	for i, to := range parts {
+
+		if err := ctx.Err(); err != nil {
+			return ids, newPartialResult(ids, i, len(parts), err)
+		}
+
+		id, err := send(to, i == 0)
+
+		if err != nil {
+
+			if len(parts) > 1 {
+				err = fmt.Errorf("part %d of %d: %w", i+1, len(parts), err)
+			}
+
+			return ids, newPartialResult(ids, i, len(parts), err)
+
+		}
+
+		ids = append(ids, id)
+
+	}
+
+	return ids, nil
+
+}
@@ -0,0 +1,39 @@
+package maileroo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+type ReferenceIDGenerator interface {
+	GenerateReferenceID() string
+}
+
+type ReferenceIDGeneratorFunc func() string
+
+func (f ReferenceIDGeneratorFunc) GenerateReferenceID() string {
+	return f()
+}
+
+func WithReferenceIDGenerator(g ReferenceIDGenerator) ClientOption {
+	return func(c *Client) error {
+		if g == nil {
+			return errors.New("reference ID generator must not be nil")
+		}
+		c.referenceIDGenerator = g
+		return nil
+	}
+}
+
+func DeterministicReferenceID(seed string) string {
+
+	sum := sha256.Sum256([]byte(seed))
+
+	return hex.EncodeToString(sum[:ReferenceIDLength/2])
+
+}
+
+func NamespacedReferenceID(namespace, key string) string {
+	return DeterministicReferenceID(namespace + "\x00" + key)
+}
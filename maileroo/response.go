@@ -0,0 +1,114 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+type APIResponse[T any] struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    T              `json:"data"`
+	Errors  APIFieldErrors `json:"errors,omitempty"`
+}
+
+func (r *APIResponse[T]) UnmarshalJSON(b []byte) error {
+
+	var aux struct {
+		Success bool            `json:"success"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+		Errors  APIFieldErrors  `json:"errors"`
+	}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	r.Success = aux.Success
+	r.Message = aux.Message
+	r.Errors = aux.Errors
+
+	if len(aux.Data) == 0 {
+		return nil
+	}
+
+	err := json.Unmarshal(aux.Data, &r.Data)
+
+	if r.Success {
+		return err
+	}
+
+	var data struct {
+		Errors APIFieldErrors `json:"errors"`
+	}
+
+	if json.Unmarshal(aux.Data, &data) == nil {
+		r.Errors = mergeFieldErrors(r.Errors, data.Errors)
+	}
+
+	return nil
+
+}
+
+func (r *APIResponse[T]) Err() error {
+
+	if r.Success {
+		return nil
+	}
+
+	return newAPIError(r.Message, r.Errors)
+
+}
+
+func DoJSON[T any](ctx context.Context, c *Client, method, path string, body any) (T, error) {
+
+	var zero T
+
+	if c == nil {
+		return zero, errors.New("client must not be nil")
+	}
+
+	if strings.TrimSpace(method) == "" {
+		return zero, errors.New("method must be a non-empty string")
+	}
+
+	if strings.TrimSpace(path) == "" {
+		return zero, errors.New("path must be a non-empty string")
+	}
+
+	var out APIResponse[T]
+
+	if err := c.sendRequest(ctx, strings.ToUpper(method), path, body, &out); err != nil {
+		return zero, err
+	}
+
+	if err := out.Err(); err != nil {
+		return zero, err
+	}
+
+	return out.Data, nil
+
+}
+
+func doJSONData[T any](ctx context.Context, c *Client, method, path string, body any) (*T, error) {
+
+	var out APIResponse[*T]
+
+	if err := c.sendRequest(ctx, method, path, body, &out); err != nil {
+		return nil, err
+	}
+
+	if out.Data == nil {
+		out.Success = false
+	}
+
+	if err := out.Err(); err != nil {
+		return nil, err
+	}
+
+	return out.Data, nil
+
+}
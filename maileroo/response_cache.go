@@ -0,0 +1,187 @@
+package maileroo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultResponseCacheTTL = 30 * time.Second
+
+var cacheableRoutes = map[string]bool{
+	"templates": true,
+	"domains":   true,
+}
+
+type ResponseCache interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+	DeletePrefix(ctx context.Context, prefix string)
+}
+
+type memoryCacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type MemoryResponseCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+func NewMemoryResponseCache() *MemoryResponseCache {
+	return &MemoryResponseCache{entries: map[string]memoryCacheEntry{}}
+}
+
+func (m *MemoryResponseCache) Get(ctx context.Context, key string) ([]byte, bool) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(e.expiresAt) {
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	return append([]byte(nil), e.value...), true
+
+}
+
+func (m *MemoryResponseCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = memoryCacheEntry{value: append([]byte(nil), value...), expiresAt: time.Now().Add(ttl)}
+
+}
+
+func (m *MemoryResponseCache) DeletePrefix(ctx context.Context, prefix string) {
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k := range m.entries {
+
+		if strings.HasPrefix(k, prefix) {
+			delete(m.entries, k)
+		}
+
+	}
+
+}
+
+func WithResponseCache(cache ResponseCache, ttl time.Duration) ClientOption {
+	return func(c *Client) error {
+		if ttl < 0 {
+			return errors.New("response cache TTL must not be negative")
+		}
+		if ttl == 0 {
+			ttl = DefaultResponseCacheTTL
+		}
+		if cache == nil {
+			cache = NewMemoryResponseCache()
+		}
+		c.responseCache = cache
+		c.responseCacheTTL = ttl
+		return nil
+	}
+}
+
+func (c *Client) ClearResponseCache(ctx context.Context) {
+
+	if c.responseCache == nil {
+		return
+	}
+
+	c.responseCache.DeletePrefix(ctx, c.responseCacheKeyspace())
+
+}
+
+func (c *Client) responseCacheKeyspace() string {
+
+	sum := sha256.Sum256([]byte(c.APIKey + "\x00" + c.apiBaseURL))
+
+	return hex.EncodeToString(sum[:8]) + "|"
+
+}
+
+func responseCacheRoute(endpoint string) string {
+
+	path := strings.TrimLeft(endpoint, "/")
+	route, _, _ := strings.Cut(path, "/")
+	route, _, _ = strings.Cut(route, "?")
+
+	return route
+
+}
+
+func (c *Client) sendCachedRequest(ctx context.Context, method, endpoint string, body any, out any) error {
+
+	keyspace := c.responseCacheKeyspace()
+	route := responseCacheRoute(endpoint)
+
+	if method != http.MethodGet {
+
+		err := c.doRequest(ctx, method, endpoint, body, out)
+
+		c.responseCache.DeletePrefix(ctx, keyspace+route)
+
+		return err
+
+	}
+
+	if strings.HasPrefix(endpoint, "http") || !cacheableRoutes[route] {
+		return c.doRequest(ctx, method, endpoint, body, out)
+	}
+
+	key := keyspace + strings.TrimLeft(endpoint, "/")
+
+	if raw, ok := c.responseCache.Get(ctx, key); ok && json.Unmarshal(raw, out) == nil {
+		captureRawResponse(ctx, raw)
+		return nil
+	}
+
+	dst, _ := ctx.Value(rawResponseKey{}).(*json.RawMessage)
+	meta, _ := ctx.Value(responseMetaKey{}).(*responseMeta)
+
+	if meta == nil {
+		meta = &responseMeta{}
+		ctx = withResponseMeta(ctx, meta)
+	}
+
+	var raw json.RawMessage
+
+	err := c.doRequest(WithRawResponse(ctx, &raw), method, endpoint, body, out)
+
+	if dst != nil && raw != nil {
+		*dst = append((*dst)[:0], raw...)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var envelope struct {
+		Success bool `json:"success"`
+	}
+
+	if meta.status == http.StatusOK && json.Unmarshal(raw, &envelope) == nil && envelope.Success {
+		c.responseCache.Set(ctx, key, raw, c.responseCacheTTL)
+	}
+
+	return nil
+
+}
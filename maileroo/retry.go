@@ -0,0 +1,191 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	mathrand "math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetryAttempts   = 3
+	DefaultRetryMinBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+type RetryDecision int
+
+const (
+	RetryDecisionDefault RetryDecision = iota
+	RetryDecisionRetry
+	RetryDecisionStop
+)
+
+type RetryResponse struct {
+	Method     string
+	Endpoint   string
+	Attempt    int
+	StatusCode int
+	Header     http.Header
+	Message    string
+	Body       []byte
+	ErrorClass ErrorClass
+}
+
+type RetryClassifier func(resp *RetryResponse, err error) RetryDecision
+
+type RetryPolicy struct {
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+	Classifier  RetryClassifier
+}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxAttempts < 0 || policy.MinBackoff < 0 || policy.MaxBackoff < 0 {
+			return errors.New("retry attempts and backoff must not be negative")
+		}
+		if policy.MaxAttempts == 0 {
+			policy.MaxAttempts = DefaultRetryAttempts
+		}
+		if policy.MinBackoff == 0 {
+			policy.MinBackoff = DefaultRetryMinBackoff
+		}
+		if policy.MaxBackoff == 0 {
+			policy.MaxBackoff = DefaultRetryMaxBackoff
+		}
+		if policy.MaxBackoff < policy.MinBackoff {
+			return errors.New("max backoff must not be shorter than min backoff")
+		}
+		c.retryPolicy = &policy
+		return nil
+	}
+}
+
+func DefaultRetryClassifier(resp *RetryResponse, err error) RetryDecision {
+
+	if resp.ErrorClass == ErrorClassCanceled {
+		return RetryDecisionStop
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return RetryDecisionRetry
+	}
+
+	if err != nil && isDialError(err) {
+		return RetryDecisionRetry
+	}
+
+	if resp.Method == http.MethodPost {
+		return RetryDecisionStop
+	}
+
+	switch resp.StatusCode {
+
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return RetryDecisionRetry
+
+	}
+
+	switch resp.ErrorClass {
+
+	case ErrorClassNetwork, ErrorClassTimeout:
+		return RetryDecisionRetry
+
+	}
+
+	return RetryDecisionStop
+
+}
+
+func (c *Client) shouldRetry(ctx context.Context, resp *RetryResponse, err error) (time.Duration, bool) {
+
+	p := c.retryPolicy
+
+	if p == nil || resp.Attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return 0, false
+	}
+
+	if err == nil && resp.ErrorClass == ErrorClassNone {
+		return 0, false
+	}
+
+	var m struct {
+		Message string `json:"message"`
+	}
+
+	if len(resp.Body) > 0 && json.Unmarshal(resp.Body, &m) == nil {
+		resp.Message = m.Message
+	}
+
+	decision := RetryDecisionDefault
+
+	if p.Classifier != nil {
+		decision = p.Classifier(resp, err)
+	}
+
+	if decision == RetryDecisionDefault {
+		decision = DefaultRetryClassifier(resp, err)
+	}
+
+	if decision != RetryDecisionRetry {
+		return 0, false
+	}
+
+	wait := p.MinBackoff << min(resp.Attempt-1, 16)
+
+	if wait <= 0 || wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	wait = wait/2 + time.Duration(mathrand.Int63n(int64(wait/2)+1))
+
+	if after, ok := retryAfter(resp.Header); ok {
+		wait = min(max(wait, after), p.MaxBackoff)
+	}
+
+	return wait, true
+
+}
+
+func retryAfter(h http.Header) (time.Duration, bool) {
+
+	v := strings.TrimSpace(h.Get("Retry-After"))
+
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+
+	case <-timer.C:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+
+	}
+
+}
@@ -0,0 +1,333 @@
+package maileroo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type HTMLSanitizer interface {
+	Sanitize(html string) (string, error)
+}
+
+type HTMLSanitizerFunc func(html string) (string, error)
+
+func (f HTMLSanitizerFunc) Sanitize(html string) (string, error) {
+	return f(html)
+}
+
+type SanitizePolicy struct {
+	TrustedImageHosts  []string
+	KeepTrackingPixels bool
+	AllowDataImages    bool
+}
+
+var droppedElements = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Iframe:   true,
+	atom.Frame:    true,
+	atom.Frameset: true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Applet:   true,
+	atom.Base:     true,
+	atom.Link:     true,
+	atom.Meta:     true,
+	atom.Form:     true,
+	atom.Noscript: true,
+}
+
+var urlAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"action":     true,
+	"formaction": true,
+	"background": true,
+	"poster":     true,
+	"srcset":     true,
+	"xlink:href": true,
+}
+
+func NewHTMLSanitizer(policy SanitizePolicy) HTMLSanitizer {
+
+	hosts := make([]string, 0, len(policy.TrustedImageHosts))
+
+	for _, h := range policy.TrustedImageHosts {
+
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			hosts = append(hosts, h)
+		}
+
+	}
+
+	policy.TrustedImageHosts = hosts
+
+	return HTMLSanitizerFunc(func(s string) (string, error) {
+		return sanitizeHTML(s, policy)
+	})
+
+}
+
+func WithHTMLSanitization(sanitizer HTMLSanitizer) ClientOption {
+	return func(c *Client) error {
+		if sanitizer == nil {
+			sanitizer = NewHTMLSanitizer(SanitizePolicy{})
+		}
+		c.htmlSanitizer = sanitizer
+		return nil
+	}
+}
+
+func (c *Client) sanitizeHTMLBody(body *string) (*string, error) {
+
+	if c.htmlSanitizer == nil || body == nil {
+		return body, nil
+	}
+
+	out, err := c.htmlSanitizer.Sanitize(*body)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to sanitize html body: %w", err)
+	}
+
+	return &out, nil
+
+}
+
+func sanitizeHTML(s string, policy SanitizePolicy) (string, error) {
+
+	z := html.NewTokenizer(strings.NewReader(s))
+
+	var b strings.Builder
+	var skip []atom.Atom
+
+	for {
+
+		tt := z.Next()
+
+		if tt == html.ErrorToken {
+
+			if errors.Is(z.Err(), io.EOF) {
+				return b.String(), nil
+			}
+
+			return "", z.Err()
+
+		}
+
+		raw := z.Raw()
+
+		switch tt {
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+
+			t := z.Token()
+
+			if len(skip) > 0 {
+
+				if tt == html.StartTagToken && t.DataAtom == skip[len(skip)-1] {
+					skip = append(skip, t.DataAtom)
+				}
+
+				continue
+
+			}
+
+			if droppedElements[t.DataAtom] {
+
+				if tt == html.StartTagToken && !isVoidElement(t.DataAtom) {
+					skip = append(skip, t.DataAtom)
+				}
+
+				continue
+
+			}
+
+			if t.DataAtom == atom.Img && !policy.allowImage(t) {
+				continue
+			}
+
+			t.Attr = sanitizeAttributes(t.Attr, policy)
+
+			b.WriteString(t.String())
+
+		case html.EndTagToken:
+
+			t := z.Token()
+
+			if len(skip) > 0 {
+
+				if t.DataAtom == skip[len(skip)-1] {
+					skip = skip[:len(skip)-1]
+				}
+
+				continue
+
+			}
+
+			if droppedElements[t.DataAtom] {
+				continue
+			}
+
+			b.Write(raw)
+
+		default:
+
+			if len(skip) == 0 {
+				b.Write(raw)
+			}
+
+		}
+
+	}
+
+}
+
+func sanitizeAttributes(attrs []html.Attribute, policy SanitizePolicy) []html.Attribute {
+
+	out := attrs[:0]
+
+	for _, a := range attrs {
+
+		key := strings.ToLower(a.Key)
+
+		if a.Namespace != "" {
+			key = a.Namespace + ":" + key
+		}
+
+		if strings.HasPrefix(key, "on") {
+			continue
+		}
+
+		if urlAttributes[key] && !isSafeURL(a.Val, policy) {
+			continue
+		}
+
+		if key == "style" && isDangerousStyle(a.Val) {
+			continue
+		}
+
+		out = append(out, a)
+
+	}
+
+	return out
+
+}
+
+func isSafeURL(raw string, policy SanitizePolicy) bool {
+
+	v := strings.ToLower(strings.Map(func(r rune) rune {
+
+		if r <= ' ' {
+			return -1
+		}
+
+		return r
+
+	}, raw))
+
+	switch {
+
+	case strings.HasPrefix(v, "javascript:"), strings.HasPrefix(v, "vbscript:"):
+		return false
+
+	case strings.HasPrefix(v, "data:"):
+		return policy.AllowDataImages && strings.HasPrefix(v, "data:image/") && !strings.HasPrefix(v, "data:image/svg")
+
+	}
+
+	return true
+
+}
+
+func isDangerousStyle(style string) bool {
+
+	v := strings.ToLower(style)
+
+	return strings.Contains(v, "expression(") || strings.Contains(v, "javascript:") || strings.Contains(v, "behavior:")
+
+}
+
+func (p SanitizePolicy) allowImage(t html.Token) bool {
+
+	var src, width, height string
+
+	for _, a := range t.Attr {
+
+		switch strings.ToLower(a.Key) {
+
+		case "src":
+			src = strings.TrimSpace(a.Val)
+
+		case "width":
+			width = strings.TrimSpace(a.Val)
+
+		case "height":
+			height = strings.TrimSpace(a.Val)
+
+		}
+
+	}
+
+	u, err := url.Parse(src)
+
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return true
+	}
+
+	if len(p.TrustedImageHosts) > 0 {
+
+		host := strings.ToLower(u.Hostname())
+		trusted := false
+
+		for _, h := range p.TrustedImageHosts {
+
+			if host == h || strings.HasSuffix(host, "."+h) {
+				trusted = true
+				break
+			}
+
+		}
+
+		if !trusted {
+			return false
+		}
+
+	}
+
+	if !p.KeepTrackingPixels && isTinyDimension(width) && isTinyDimension(height) {
+		return false
+	}
+
+	return true
+
+}
+
+func isTinyDimension(v string) bool {
+
+	v = strings.TrimSuffix(v, "px")
+
+	return v == "0" || v == "1"
+
+}
+
+func isVoidElement(a atom.Atom) bool {
+
+	switch a {
+
+	case atom.Area, atom.Base, atom.Br, atom.Col, atom.Embed, atom.Hr, atom.Img, atom.Input,
+		atom.Link, atom.Meta, atom.Param, atom.Source, atom.Track, atom.Wbr:
+		return true
+
+	default:
+		return false
+
+	}
+
+}
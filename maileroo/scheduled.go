@@ -0,0 +1,238 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ScheduledEmail struct {
+	ReferenceID string
+	Subject     string
+	TemplateID  *int
+	Tags        AssocMap
+	ScheduledAt time.Time
+	CreatedAt   time.Time
+	Extra       map[string]json.RawMessage
+}
+
+var scheduledEmailFields = map[string]bool{
+	"reference_id": true,
+	"subject":      true,
+	"template_id":  true,
+	"tags":         true,
+	"scheduled_at": true,
+	"created_at":   true,
+}
+
+func (s *ScheduledEmail) UnmarshalJSON(b []byte) error {
+
+	var raw map[string]json.RawMessage
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if v, ok := raw["reference_id"]; ok {
+		_ = json.Unmarshal(v, &s.ReferenceID)
+	}
+
+	if v, ok := raw["subject"]; ok {
+		_ = json.Unmarshal(v, &s.Subject)
+	}
+
+	if v, ok := raw["template_id"]; ok {
+
+		var id int
+
+		if json.Unmarshal(v, &id) == nil && id > 0 {
+			s.TemplateID = &id
+		}
+
+	}
+
+	if v, ok := raw["tags"]; ok {
+		_ = json.Unmarshal(v, &s.Tags)
+	}
+
+	if v, ok := raw["scheduled_at"]; ok {
+		s.ScheduledAt = parseAPITime(v)
+	}
+
+	if v, ok := raw["created_at"]; ok {
+		s.CreatedAt = parseAPITime(v)
+	}
+
+	s.Extra = unknownFields(raw, scheduledEmailFields)
+
+	return nil
+
+}
+
+var apiTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+func parseAPITime(raw json.RawMessage) time.Time {
+
+	var s string
+
+	if err := json.Unmarshal(raw, &s); err != nil {
+
+		var unix int64
+
+		if json.Unmarshal(raw, &unix) == nil && unix > 0 {
+			return time.Unix(unix, 0).UTC()
+		}
+
+		return time.Time{}
+
+	}
+
+	for _, layout := range apiTimeLayouts {
+
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+
+	}
+
+	return time.Time{}
+
+}
+
+type ScheduledEmailSort string
+
+const (
+	SortScheduledAtAsc  ScheduledEmailSort = "scheduled_at"
+	SortScheduledAtDesc ScheduledEmailSort = "-scheduled_at"
+	SortCreatedAtAsc    ScheduledEmailSort = "created_at"
+	SortCreatedAtDesc   ScheduledEmailSort = "-created_at"
+)
+
+func applyScheduledSort(q url.Values, sort []ScheduledEmailSort) error {
+
+	if len(sort) == 0 {
+		return nil
+	}
+
+	if len(sort) > 1 {
+		return newFieldError("sort", FieldErrorTooMany, "only one sort order can be given")
+	}
+
+	switch sort[0] {
+
+	case SortScheduledAtAsc, SortCreatedAtAsc:
+		q.Set("sort_by", string(sort[0]))
+		q.Set("sort_order", "asc")
+
+	case SortScheduledAtDesc, SortCreatedAtDesc:
+		q.Set("sort_by", strings.TrimPrefix(string(sort[0]), "-"))
+		q.Set("sort_order", "desc")
+
+	default:
+		return fmt.Errorf("unknown sort order %q", sort[0])
+
+	}
+
+	return nil
+
+}
+
+type scheduledPage struct {
+	Page       int              `json:"page"`
+	PerPage    int              `json:"per_page"`
+	TotalCount int              `json:"total_count"`
+	TotalPages int              `json:"total_pages"`
+	Items      []ScheduledEmail `json:"results"`
+}
+
+func (c *Client) IterateScheduledEmails(ctx context.Context, perPage int, sort ...ScheduledEmailSort) (*Iterator[ScheduledEmail], error) {
+
+	if perPage < 1 {
+		return nil, newFieldError("per_page", FieldErrorInvalid, "per_page must be a positive integer (>= 1)")
+	}
+
+	if perPage > 100 {
+		return nil, newFieldError("per_page", FieldErrorInvalid, "per_page cannot be greater than 100")
+	}
+
+	sortQuery := url.Values{}
+
+	if err := applyScheduledSort(sortQuery, sort); err != nil {
+		return nil, err
+	}
+
+	return c.iterateScheduledEmails(ctx, perPage, sortQuery), nil
+
+}
+
+func (c *Client) iterateScheduledEmails(ctx context.Context, perPage int, base url.Values) *Iterator[ScheduledEmail] {
+
+	fetch := func(ctx context.Context, req pageRequest) (pageResult[ScheduledEmail], error) {
+
+		q := url.Values{}
+
+		q.Set("page", strconv.Itoa(req.page))
+		q.Set("per_page", strconv.Itoa(perPage))
+
+		for k, v := range base {
+			q[k] = v
+		}
+
+		page, err := doJSONData[scheduledPage](ctx, c, http.MethodGet, "emails/scheduled?"+q.Encode(), nil)
+
+		if err != nil {
+			return pageResult[ScheduledEmail]{}, err
+		}
+
+		res := pageResult[ScheduledEmail]{items: page.Items}
+
+		if page.Page < page.TotalPages {
+			res.nextPage = page.Page + 1
+		}
+
+		return res, nil
+
+	}
+
+	return newIterator(ctx, fetch)
+
+}
+
+func (c *Client) DeleteScheduledEmails(ctx context.Context, referenceIDs []string) error {
+
+	for _, id := range referenceIDs {
+
+		if err := validateReferenceID(id); err != nil {
+			return err
+		}
+
+	}
+
+	deleted := make([]string, 0, len(referenceIDs))
+
+	for i, id := range referenceIDs {
+
+		if err := ctx.Err(); err != nil {
+			return newPartialResult(deleted, i, len(referenceIDs), err)
+		}
+
+		if err := c.DeleteScheduledEmail(ctx, id); err != nil {
+			return newPartialResult(deleted, i, len(referenceIDs), fmt.Errorf("reference_id %s: %w", id, err))
+		}
+
+		deleted = append(deleted, id)
+
+	}
+
+	return nil
+
+}
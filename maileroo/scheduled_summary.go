@@ -0,0 +1,148 @@
+package maileroo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const NoTemplateID = 0
+
+type ScheduledEmailQuery struct {
+	Start      time.Time
+	End        time.Time
+	TemplateID *int
+	Tags       AssocMap
+	Location   *time.Location
+}
+
+type ScheduledEmailSummary struct {
+	Total      int
+	ByDay      map[string]int
+	ByTemplate map[int]int
+	ByTag      map[string]int
+}
+
+func (q ScheduledEmailQuery) values() (url.Values, error) {
+
+	if !q.Start.IsZero() && !q.End.IsZero() && !q.End.After(q.Start) {
+		return nil, newFieldError("end", FieldErrorInvalid, "end must be after start")
+	}
+
+	if q.TemplateID != nil && *q.TemplateID < 1 {
+		return nil, newFieldError("template_id", FieldErrorInvalid, "template_id must be a positive integer")
+	}
+
+	v := url.Values{}
+
+	if !q.Start.IsZero() {
+		v.Set("start_date", q.Start.UTC().Format(time.RFC3339))
+	}
+
+	if !q.End.IsZero() {
+		v.Set("end_date", q.End.UTC().Format(time.RFC3339))
+	}
+
+	if q.TemplateID != nil {
+		v.Set("template_id", strconv.Itoa(*q.TemplateID))
+	}
+
+	addTagFilters(v, q.Tags)
+
+	return v, nil
+
+}
+
+func addTagFilters(v url.Values, tags AssocMap) {
+
+	keys := make([]string, 0, len(tags))
+
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v.Add("tag", fmt.Sprintf("%s:%v", k, tags[k]))
+	}
+
+}
+
+func (c *Client) CountScheduledEmails(ctx context.Context, query ScheduledEmailQuery) (int, error) {
+
+	q, err := query.values()
+
+	if err != nil {
+		return 0, err
+	}
+
+	q.Set("page", "1")
+	q.Set("per_page", "1")
+
+	page, err := doJSONData[scheduledPage](ctx, c, http.MethodGet, "emails/scheduled?"+q.Encode(), nil)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return page.TotalCount, nil
+
+}
+
+func (c *Client) SummarizeScheduledEmails(ctx context.Context, query ScheduledEmailQuery) (*ScheduledEmailSummary, error) {
+
+	q, err := query.values()
+
+	if err != nil {
+		return nil, err
+	}
+
+	loc := query.Location
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	summary := &ScheduledEmailSummary{
+		ByDay:      map[string]int{},
+		ByTemplate: map[int]int{},
+		ByTag:      map[string]int{},
+	}
+
+	it := c.iterateScheduledEmails(ctx, 100, q)
+	defer it.Close()
+
+	for it.Next() {
+
+		e := it.Item()
+
+		summary.Total++
+
+		if !e.ScheduledAt.IsZero() {
+			summary.ByDay[e.ScheduledAt.In(loc).Format("2006-01-02")]++
+		}
+
+		if e.TemplateID != nil {
+			summary.ByTemplate[*e.TemplateID]++
+		} else {
+			summary.ByTemplate[NoTemplateID]++
+		}
+
+		for k, v := range e.Tags {
+			summary.ByTag[fmt.Sprintf("%s:%v", k, v)]++
+		}
+
+	}
+
+	if err := it.Err(); err != nil {
+		return summary, err
+	}
+
+	return summary, nil
+
+}
@@ -0,0 +1,96 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type SendStatus string
+
+const (
+	SendStatusQueued    SendStatus = "queued"
+	SendStatusScheduled SendStatus = "scheduled"
+	SendStatusSent      SendStatus = "sent"
+)
+
+type SendResult struct {
+	ReferenceID string
+	Status      SendStatus
+	ScheduledAt *time.Time
+	MessageIDs  []string
+	Message     string
+	Attachments []AttachmentChecksum
+	Skipped     []SkippedRecipient
+	Lint        *LintReport
+}
+
+func (c *Client) postEmail(ctx context.Context, endpoint string, payload map[string]any, scheduledAt *time.Time) (*SendResult, error) {
+
+	var out struct {
+		Success bool   `json:"success"`
+		Message string `json:"message"`
+		Data    struct {
+			ReferenceID string          `json:"reference_id"`
+			Status      string          `json:"status"`
+			ScheduledAt json.RawMessage `json:"scheduled_at"`
+			MessageID   string          `json:"message_id"`
+			MessageIDs  []string        `json:"message_ids"`
+			Errors      APIFieldErrors  `json:"errors"`
+		} `json:"data"`
+		Errors APIFieldErrors `json:"errors"`
+	}
+
+	body := c.emailRequestBody(payload)
+
+	if probed, err := probePayload(ctx, payload, body); probed {
+		return &SendResult{}, err
+	}
+
+	if err := c.sendRequest(ctx, http.MethodPost, endpoint, body, &out); err != nil {
+		return nil, err
+	}
+
+	if !out.Success {
+		return nil, newAPIError(out.Message, out.Errors, out.Data.Errors)
+	}
+
+	res := &SendResult{
+		ReferenceID: out.Data.ReferenceID,
+		Status:      SendStatus(out.Data.Status),
+		MessageIDs:  out.Data.MessageIDs,
+		Message:     out.Message,
+		Attachments: c.attachmentChecksumsFor(payload),
+	}
+
+	if out.Data.MessageID != "" && len(res.MessageIDs) == 0 {
+		res.MessageIDs = []string{out.Data.MessageID}
+	}
+
+	if len(out.Data.ScheduledAt) > 0 {
+
+		if t := parseAPITime(out.Data.ScheduledAt); !t.IsZero() {
+			res.ScheduledAt = &t
+		}
+
+	}
+
+	if res.ScheduledAt == nil && scheduledAt != nil {
+		t := *scheduledAt
+		res.ScheduledAt = &t
+	}
+
+	if res.Status == "" {
+
+		if res.ScheduledAt != nil {
+			res.Status = SendStatusScheduled
+		} else {
+			res.Status = SendStatusQueued
+		}
+
+	}
+
+	return res, nil
+
+}
@@ -0,0 +1,172 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type AttachmentInfo struct {
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Inline      bool   `json:"inline"`
+}
+
+type SentEmail struct {
+	ReferenceID string           `json:"reference_id"`
+	Status      string           `json:"status"`
+	Subject     string           `json:"subject"`
+	From        EmailAddress     `json:"from"`
+	To          []EmailAddress   `json:"to"`
+	Cc          []EmailAddress   `json:"cc"`
+	Bcc         []EmailAddress   `json:"bcc"`
+	ReplyTo     []EmailAddress   `json:"reply_to"`
+	HTML        *string          `json:"html"`
+	Plain       *string          `json:"plain"`
+	TemplateID  *int             `json:"template_id"`
+	Tags        AssocMap         `json:"tags"`
+	Headers     AssocMap         `json:"headers"`
+	Attachments []AttachmentInfo `json:"attachments"`
+	CreatedAt   time.Time        `json:"-"`
+	SentAt      time.Time        `json:"-"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+var sentEmailFields = jsonFieldNames(SentEmail{}, "created_at", "sent_at")
+
+func (s *SentEmail) UnmarshalJSON(b []byte) error {
+
+	type plain SentEmail
+
+	var aux struct {
+		*plain
+		CreatedAt json.RawMessage `json:"created_at"`
+		SentAt    json.RawMessage `json:"sent_at"`
+	}
+
+	aux.plain = (*plain)(s)
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.CreatedAt) > 0 {
+		s.CreatedAt = parseAPITime(aux.CreatedAt)
+	}
+
+	if len(aux.SentAt) > 0 {
+		s.SentAt = parseAPITime(aux.SentAt)
+	}
+
+	s.Extra = captureExtra(b, sentEmailFields)
+
+	return nil
+
+}
+
+type EmailQuery struct {
+	Start     time.Time
+	End       time.Time
+	Status    string
+	Recipient string
+	Tags      AssocMap
+	Domain    string
+	PerPage   int
+}
+
+type sentEmailsPage struct {
+	Page       int         `json:"page"`
+	PerPage    int         `json:"per_page"`
+	TotalCount int         `json:"total_count"`
+	TotalPages int         `json:"total_pages"`
+	NextCursor string      `json:"next_cursor"`
+	Items      []SentEmail `json:"results"`
+}
+
+func (c *Client) ListEmails(ctx context.Context, query EmailQuery) (*Iterator[SentEmail], error) {
+
+	if query.PerPage == 0 {
+		query.PerPage = 100
+	}
+
+	if query.PerPage < 1 || query.PerPage > 100 {
+		return nil, newFieldError("per_page", FieldErrorInvalid, "per_page must be between 1 and 100")
+	}
+
+	if !query.Start.IsZero() && !query.End.IsZero() && !query.End.After(query.Start) {
+		return nil, newFieldError("end", FieldErrorInvalid, "end must be after start")
+	}
+
+	for field, v := range map[string]string{"status": query.Status, "recipient": query.Recipient, "domain": query.Domain} {
+
+		if containsControl(v) {
+			return nil, newInjectionError(field, field)
+		}
+
+	}
+
+	base := url.Values{}
+
+	base.Set("per_page", strconv.Itoa(query.PerPage))
+
+	if !query.Start.IsZero() {
+		base.Set("start_date", query.Start.UTC().Format(time.RFC3339))
+	}
+
+	if !query.End.IsZero() {
+		base.Set("end_date", query.End.UTC().Format(time.RFC3339))
+	}
+
+	if s := strings.TrimSpace(query.Status); s != "" {
+		base.Set("status", s)
+	}
+
+	if r := strings.TrimSpace(query.Recipient); r != "" {
+		base.Set("recipient", r)
+	}
+
+	if d := strings.ToLower(strings.TrimSpace(query.Domain)); d != "" {
+		base.Set("domain", d)
+	}
+
+	addTagFilters(base, query.Tags)
+
+	fetch := func(ctx context.Context, req pageRequest) (pageResult[SentEmail], error) {
+
+		q := url.Values{}
+
+		for k, v := range base {
+			q[k] = v
+		}
+
+		if req.cursor != "" {
+			q.Set("cursor", req.cursor)
+		} else {
+			q.Set("page", strconv.Itoa(req.page))
+		}
+
+		page, err := doJSONData[sentEmailsPage](ctx, c, http.MethodGet, "emails?"+q.Encode(), nil)
+
+		if err != nil {
+			return pageResult[SentEmail]{}, err
+		}
+
+		res := pageResult[SentEmail]{items: page.Items, nextCursor: page.NextCursor}
+
+		if res.nextCursor == "" && page.Page < page.TotalPages {
+			res.nextPage = page.Page + 1
+		}
+
+		return res, nil
+
+	}
+
+	return newIterator(ctx, fetch), nil
+
+}
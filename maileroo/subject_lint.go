@@ -0,0 +1,240 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+)
+
+const (
+	LintSubjectTooLong  = "subject_too_long"
+	LintSubjectAllCaps  = "subject_all_caps"
+	LintSubjectEmoji    = "subject_emoji"
+	LintSubjectSpamWord = "subject_spam_word"
+)
+
+const (
+	DefaultSubjectLintLength = 78
+	DefaultSubjectLintEmoji  = 2
+	minAllCapsLetters        = 5
+)
+
+var defaultSpamTriggerWords = []string{
+	"!!!", "$$$", "100% free", "act now", "buy now", "cash bonus", "click here", "congratulations",
+	"double your", "earn money", "free", "guarantee", "limited time", "no cost", "risk-free",
+	"urgent", "winner", "you have been selected",
+}
+
+type SubjectLintOptions struct {
+	MaxLength int
+	MaxEmoji  int
+	SpamWords []string
+}
+
+type LintReport struct {
+	Subject  string
+	Warnings []ContentIssue
+}
+
+func (r *LintReport) HasWarnings() bool {
+	return r != nil && len(r.Warnings) > 0
+}
+
+func DefaultSpamTriggerWords() []string {
+	return append([]string(nil), defaultSpamTriggerWords...)
+}
+
+func WithSubjectLint(opts SubjectLintOptions) ClientOption {
+	return func(c *Client) error {
+		if opts.MaxLength < 0 || opts.MaxEmoji < 0 {
+			return errors.New("subject lint length and emoji limits must not be negative")
+		}
+		opts.SpamWords = append([]string(nil), opts.SpamWords...)
+		c.subjectLint = &opts
+		return nil
+	}
+}
+
+func LintSubject(subject string, opts SubjectLintOptions) *LintReport {
+
+	maxLen := opts.MaxLength
+
+	if maxLen == 0 {
+		maxLen = DefaultSubjectLintLength
+	}
+
+	maxEmoji := opts.MaxEmoji
+
+	if maxEmoji == 0 {
+		maxEmoji = DefaultSubjectLintEmoji
+	}
+
+	words := opts.SpamWords
+
+	if words == nil {
+		words = defaultSpamTriggerWords
+	}
+
+	report := &LintReport{Subject: subject}
+
+	warn := func(code, format string, args ...any) {
+		report.Warnings = append(report.Warnings, ContentIssue{Field: "subject", Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n := runeLen(subject); n > maxLen {
+		warn(LintSubjectTooLong, "subject has %d characters; many clients cut it off after about %d", n, maxLen)
+	}
+
+	text := mergeTagRe.ReplaceAllString(subject, " ")
+
+	upper, lower, emoji := 0, 0, 0
+
+	for _, r := range text {
+
+		switch {
+
+		case unicode.IsUpper(r):
+			upper++
+
+		case unicode.IsLower(r):
+			lower++
+
+		case unicode.Is(unicode.So, r):
+			emoji++
+
+		}
+
+	}
+
+	if upper >= minAllCapsLetters && lower == 0 {
+		warn(LintSubjectAllCaps, "subject is written in capitals only")
+	}
+
+	if emoji > maxEmoji {
+		warn(LintSubjectEmoji, "subject has %d emoji; more than %d tends to look like spam", emoji, maxEmoji)
+	}
+
+	lowered := strings.ToLower(text)
+
+	for _, w := range words {
+
+		if containsPhrase(lowered, strings.ToLower(strings.TrimSpace(w))) {
+			warn(LintSubjectSpamWord, "subject contains the spam trigger %q", w)
+		}
+
+	}
+
+	return report
+
+}
+
+func containsPhrase(s, phrase string) bool {
+
+	if phrase == "" {
+		return false
+	}
+
+	for start := 0; ; {
+
+		i := strings.Index(s[start:], phrase)
+
+		if i < 0 {
+			return false
+		}
+
+		i += start
+		end := i + len(phrase)
+
+		if boundaryBefore(s, i, phrase) && boundaryAfter(s, end, phrase) {
+			return true
+		}
+
+		start = i + 1
+
+	}
+
+}
+
+func boundaryBefore(s string, i int, phrase string) bool {
+
+	first := []rune(phrase)[0]
+
+	if i == 0 || !isWordRune(first) {
+		return true
+	}
+
+	prev := []rune(s[:i])
+
+	return !isWordRune(prev[len(prev)-1])
+
+}
+
+func boundaryAfter(s string, end int, phrase string) bool {
+
+	runes := []rune(phrase)
+
+	if end >= len(s) || !isWordRune(runes[len(runes)-1]) {
+		return true
+	}
+
+	return !isWordRune([]rune(s[end:])[0])
+
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (c *Client) lintBulkSubjects(ctx context.Context, data BulkEmailData) {
+
+	if c.subjectLint == nil || bulkPrechecked(ctx) {
+		return
+	}
+
+	seen := map[string]bool{}
+
+	for _, m := range data.Messages {
+
+		subject := m.Subject
+
+		if subject == "" {
+			subject = data.Subject
+		}
+
+		if !seen[subject] {
+			seen[subject] = true
+			c.lintSubject(ctx, subject)
+		}
+
+	}
+
+}
+
+func (c *Client) lintSubject(ctx context.Context, subject string) *LintReport {
+
+	if c.subjectLint == nil {
+		return nil
+	}
+
+	report := LintSubject(subject, *c.subjectLint)
+
+	if !report.HasWarnings() {
+		return nil
+	}
+
+	logger := c.logger
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	for _, w := range report.Warnings {
+		logger.WarnContext(ctx, "maileroo: subject lint", slog.String("code", w.Code), slog.String("issue", w.Message))
+	}
+
+	return report
+
+}
@@ -0,0 +1,189 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	DefaultEventPollInterval = 30 * time.Second
+	maxEventPollBackoff      = 5 * time.Minute
+)
+
+type EventSubscription struct {
+	Types        []string
+	ReferenceID  string
+	Since        time.Time
+	PollInterval time.Duration
+	Buffer       int
+	OnError      func(err error)
+}
+
+func (c *Client) SubscribeEvents(ctx context.Context, sub EventSubscription) (<-chan EmailEvent, error) {
+
+	if sub.PollInterval == 0 {
+		sub.PollInterval = DefaultEventPollInterval
+	}
+
+	if sub.PollInterval < time.Second {
+		return nil, errors.New("poll interval must be at least one second")
+	}
+
+	if sub.Buffer < 0 {
+		return nil, errors.New("buffer must not be negative")
+	}
+
+	if sub.ReferenceID != "" {
+
+		if err := validateReferenceID(sub.ReferenceID); err != nil {
+			return nil, err
+		}
+
+	}
+
+	since := sub.Since
+
+	if since.IsZero() {
+		since = time.Now()
+	}
+
+	ctx, release, err := c.lifecycle.background(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan EmailEvent, sub.Buffer)
+
+	go func() {
+		defer release()
+		c.pollEvents(ctx, sub, since.UTC().Truncate(time.Second), ch)
+	}()
+
+	return ch, nil
+
+}
+
+func (c *Client) pollEvents(ctx context.Context, sub EventSubscription, since time.Time, ch chan<- EmailEvent) {
+
+	defer close(ch)
+
+	seen := map[string]time.Time{}
+	wait := time.Duration(0)
+	failures := 0
+
+	for {
+
+		if wait > 0 {
+
+			timer := time.NewTimer(wait)
+
+			select {
+
+			case <-ctx.Done():
+				timer.Stop()
+				return
+
+			case <-timer.C:
+
+			}
+
+		}
+
+		until := time.Now().UTC().Truncate(time.Second).Add(time.Second)
+
+		if !until.After(since) {
+			wait = sub.PollInterval
+			continue
+		}
+
+		latest, err := c.pollEventsOnce(ctx, sub, since, until, seen, ch)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+
+			failures++
+
+			if sub.OnError != nil {
+				sub.OnError(err)
+			}
+
+			wait = min(sub.PollInterval<<min(failures, 8), maxEventPollBackoff)
+			continue
+
+		}
+
+		failures = 0
+		wait = sub.PollInterval
+
+		if latest.After(since) {
+			since = latest
+		}
+
+		for key, ts := range seen {
+
+			if ts.Before(since) {
+				delete(seen, key)
+			}
+
+		}
+
+	}
+
+}
+
+func (c *Client) pollEventsOnce(ctx context.Context, sub EventSubscription, since, until time.Time, seen map[string]time.Time, ch chan<- EmailEvent) (time.Time, error) {
+
+	it, err := c.ExportEmailEvents(ctx, EmailEventQuery{
+		Start:       since,
+		End:         until,
+		Types:       sub.Types,
+		ReferenceID: sub.ReferenceID,
+	})
+
+	if err != nil {
+		return since, err
+	}
+
+	defer it.Close()
+
+	latest := since
+
+	for it.Next() {
+
+		ev := it.Item()
+		key := ev.ID
+
+		if key == "" {
+			key = string(ev.Raw)
+		}
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		ts := ev.Timestamp.UTC().Truncate(time.Second)
+		seen[key] = ts
+
+		select {
+
+		case ch <- ev:
+
+		case <-ctx.Done():
+			return latest, ctx.Err()
+
+		}
+
+		if ts.After(latest) {
+			latest = ts
+		}
+
+	}
+
+	return latest, it.Err()
+
+}
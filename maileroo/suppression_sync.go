@@ -0,0 +1,115 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+const (
+	SuppressionReasonHardBounce = "hard_bounce"
+	SuppressionReasonComplaint  = "complaint"
+)
+
+type Suppressor interface {
+	Suppress(ctx context.Context, address, reason string) error
+}
+
+type SuppressionSync struct {
+	suppressor   Suppressor
+	onSuppressed func(ctx context.Context, ev EmailEvent, reason string) error
+}
+
+func (c *Client) Suppress(ctx context.Context, address, reason string) error {
+
+	address = strings.TrimSpace(address)
+
+	if parsed, err := mail.ParseAddress(address); err != nil || parsed.Address != address {
+		return newFieldError("email", FieldErrorInvalid, "email is not a valid email address: %q", address)
+	}
+
+	if containsControl(reason) {
+		return newInjectionError("reason", "reason")
+	}
+
+	body := map[string]string{"email": address}
+
+	if reason != "" {
+		body["reason"] = reason
+	}
+
+	_, err := DoJSON[json.RawMessage](ctx, c, http.MethodPost, "suppressions", body)
+
+	return err
+
+}
+
+func IsHardBounce(ev EmailEvent) bool {
+
+	if ev.Type != EventBounced {
+		return false
+	}
+
+	switch strings.ToLower(ev.BounceType) {
+
+	case "hard", "permanent":
+		return true
+
+	}
+
+	return false
+
+}
+
+func NewSuppressionSync(suppressor Suppressor, onSuppressed func(ctx context.Context, ev EmailEvent, reason string) error) (*SuppressionSync, error) {
+
+	if suppressor == nil {
+		return nil, errors.New("suppressor must not be nil")
+	}
+
+	return &SuppressionSync{suppressor: suppressor, onSuppressed: onSuppressed}, nil
+
+}
+
+func (s *SuppressionSync) HandleEvent(ctx context.Context, ev EmailEvent) error {
+
+	var reason string
+
+	switch {
+
+	case IsHardBounce(ev):
+		reason = SuppressionReasonHardBounce
+
+	case ev.Type == EventComplained:
+		reason = SuppressionReasonComplaint
+
+	default:
+		return nil
+
+	}
+
+	if strings.TrimSpace(ev.Recipient) == "" {
+		return fmt.Errorf("%s event %s has no recipient to suppress", ev.Type, ev.ID)
+	}
+
+	if err := s.suppressor.Suppress(ctx, ev.Recipient, reason); err != nil {
+		return fmt.Errorf("failed to suppress %s: %w", ev.Recipient, err)
+	}
+
+	if s.onSuppressed != nil {
+		return s.onSuppressed(ctx, ev, reason)
+	}
+
+	return nil
+
+}
+
+func (s *SuppressionSync) Register(r *EventRouter) *EventRouter {
+
+	return r.On(EventBounced, s.HandleEvent).On(EventComplained, s.HandleEvent)
+
+}
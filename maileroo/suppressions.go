@@ -0,0 +1,185 @@
+package maileroo
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+type Suppression struct {
+	Address   string    `json:"email"`
+	Reason    string    `json:"reason"`
+	Source    string    `json:"source,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+var suppressionFields = jsonFieldNames(Suppression{})
+
+func (s *Suppression) UnmarshalJSON(b []byte) error {
+
+	type plain Suppression
+
+	var aux struct {
+		*plain
+		CreatedAt json.RawMessage `json:"created_at"`
+	}
+
+	aux.plain = (*plain)(s)
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.CreatedAt) > 0 {
+		s.CreatedAt = parseAPITime(aux.CreatedAt)
+	}
+
+	s.Extra = captureExtra(b, suppressionFields)
+
+	return nil
+
+}
+
+type suppressionsPage struct {
+	Page       int           `json:"page"`
+	PerPage    int           `json:"per_page"`
+	TotalCount int           `json:"total_count"`
+	TotalPages int           `json:"total_pages"`
+	NextCursor string        `json:"next_cursor"`
+	Items      []Suppression `json:"results"`
+}
+
+func (c *Client) IterateSuppressions(ctx context.Context, perPage int) (*Iterator[Suppression], error) {
+
+	if perPage < 1 || perPage > 100 {
+		return nil, newFieldError("per_page", FieldErrorInvalid, "per_page must be between 1 and 100")
+	}
+
+	fetch := func(ctx context.Context, req pageRequest) (pageResult[Suppression], error) {
+
+		q := url.Values{}
+
+		q.Set("per_page", strconv.Itoa(perPage))
+
+		if req.cursor != "" {
+			q.Set("cursor", req.cursor)
+		} else {
+			q.Set("page", strconv.Itoa(req.page))
+		}
+
+		page, err := doJSONData[suppressionsPage](ctx, c, http.MethodGet, "suppressions?"+q.Encode(), nil)
+
+		if err != nil {
+			return pageResult[Suppression]{}, err
+		}
+
+		res := pageResult[Suppression]{items: page.Items, nextCursor: page.NextCursor}
+
+		if res.nextCursor == "" && page.Page < page.TotalPages {
+			res.nextPage = page.Page + 1
+		}
+
+		return res, nil
+
+	}
+
+	return newIterator(ctx, fetch), nil
+
+}
+
+func (c *Client) ExportSuppressions(ctx context.Context, w io.Writer, format ExportFormat) (int, error) {
+
+	if w == nil {
+		return 0, errors.New("writer must not be nil")
+	}
+
+	var write func(s Suppression) error
+	var flush func() error
+
+	switch format {
+
+	case ExportFormatCSV:
+
+		cw := csv.NewWriter(w)
+
+		if err := cw.Write([]string{"email", "reason", "source", "created_at"}); err != nil {
+			return 0, err
+		}
+
+		write = func(s Suppression) error {
+			return cw.Write([]string{s.Address, s.Reason, s.Source, formatExportTime(s.CreatedAt)})
+		}
+
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+
+	case ExportFormatNDJSON:
+
+		enc := json.NewEncoder(w)
+
+		write = func(s Suppression) error {
+			return enc.Encode(s)
+		}
+
+		flush = func() error { return nil }
+
+	default:
+		return 0, fmt.Errorf("unknown export format %q: expected %q or %q", format, ExportFormatCSV, ExportFormatNDJSON)
+
+	}
+
+	it, err := c.IterateSuppressions(ctx, 100)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer it.Close()
+
+	n := 0
+
+	for it.Next() {
+
+		if err := write(it.Item()); err != nil {
+			return n, fmt.Errorf("failed to write suppression: %w", err)
+		}
+
+		n++
+
+	}
+
+	if err := flush(); err != nil {
+		return n, fmt.Errorf("failed to write suppressions: %w", err)
+	}
+
+	return n, it.Err()
+
+}
+
+func formatExportTime(t time.Time) string {
+
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+
+}
@@ -0,0 +1,110 @@
+package maileroo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var ReservedTagKeys = []string{ABVariantTagKey, LinkedAttachmentsTagKey}
+
+type TagBuilder struct {
+	tags     AssocMap
+	err      error
+	warnings []string
+}
+
+func NewTagBuilder() *TagBuilder {
+	return &TagBuilder{tags: AssocMap{}}
+}
+
+func (b *TagBuilder) Set(key, value string) *TagBuilder {
+	return b.set(key, value)
+}
+
+func (b *TagBuilder) SetInt(key string, value int64) *TagBuilder {
+	return b.set(key, value)
+}
+
+func (b *TagBuilder) SetBool(key string, value bool) *TagBuilder {
+	return b.set(key, value)
+}
+
+func (b *TagBuilder) Merge(tags AssocMap) *TagBuilder {
+
+	keys := make([]string, 0, len(tags))
+
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		b.set(k, tags[k])
+	}
+
+	return b
+
+}
+
+func (b *TagBuilder) Warnings() []string {
+	return append([]string(nil), b.warnings...)
+}
+
+func (b *TagBuilder) Build() (AssocMap, error) {
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return mergeAssocMaps(nil, b.tags), nil
+
+}
+
+func (b *TagBuilder) set(key string, value any) *TagBuilder {
+
+	if b.err != nil {
+		return b
+	}
+
+	if err := validateAssociativeMap(AssocMap{key: value}, "tags"); err != nil {
+		b.err = err
+		return b
+	}
+
+	if isReservedTagKey(key) {
+		b.warnings = append(b.warnings, fmt.Sprintf("tag %q is reserved by the SDK and may be overwritten", key))
+	}
+
+	b.tags[key] = value
+
+	return b
+
+}
+
+func isReservedTagKey(key string) bool {
+
+	for _, r := range ReservedTagKeys {
+
+		if strings.EqualFold(r, key) {
+			return true
+		}
+
+	}
+
+	return false
+
+}
+
+func MergeTags(layers ...AssocMap) AssocMap {
+
+	var out AssocMap
+
+	for _, l := range layers {
+		out = mergeAssocMaps(out, l)
+	}
+
+	return out
+
+}
@@ -0,0 +1,57 @@
+package maileroo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	MaxTemplateDataDepth = 32
+	MaxTemplateDataSize  = 512 * 1024
+)
+
+func TemplateDataFrom(v any) (map[string]any, error) {
+
+	if v == nil {
+		return nil, errors.New("template data must not be nil")
+	}
+
+	if m, ok := v.(map[string]any); ok {
+
+		if err := validateTemplateData(m); err != nil {
+			return nil, err
+		}
+
+		return m, nil
+
+	}
+
+	b, err := json.Marshal(v)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode template data: %w", err)
+	}
+
+	if len(b) > MaxTemplateDataSize {
+		return nil, newFieldError("template_data", FieldErrorTooLarge, "template_data encodes to %d bytes, exceeding the maximum of %d", len(b), MaxTemplateDataSize)
+	}
+
+	if len(b) == 0 || b[0] != '{' {
+		return nil, newFieldError("template_data", FieldErrorInvalid, "template_data must encode to a JSON object, got %T", v)
+	}
+
+	data, err := DecodeTemplateData(bytes.NewReader(b))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTemplateData(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+
+}
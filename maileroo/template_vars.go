@@ -0,0 +1,267 @@
+package maileroo
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type TemplateVariable struct {
+	Name     string
+	Optional bool
+}
+
+var templateVarRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+type templateBlock struct {
+	conditional bool
+	scoped      bool
+}
+
+func (c *Client) ExtractTemplateVariables(ctx context.Context, templateID int) ([]TemplateVariable, error) {
+
+	tpl, err := c.GetTemplate(ctx, templateID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tpl.Variables(), nil
+
+}
+
+func (tc *TemplateCache) Variables(ctx context.Context, templateID int) ([]TemplateVariable, error) {
+
+	tpl, err := tc.Get(ctx, templateID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tpl.Variables(), nil
+
+}
+
+func (t *Template) Variables() []TemplateVariable {
+	return ParseTemplateVariables(t.Subject, t.HTML, t.Plain)
+}
+
+func ParseTemplateVariables(texts ...string) []TemplateVariable {
+
+	optional := map[string]bool{}
+
+	for _, text := range texts {
+		parseTemplateText(text, optional)
+	}
+
+	vars := make([]TemplateVariable, 0, len(optional))
+
+	for name, opt := range optional {
+		vars = append(vars, TemplateVariable{Name: name, Optional: opt})
+	}
+
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+
+	return vars
+
+}
+
+func parseTemplateText(text string, optional map[string]bool) {
+
+	var stack []templateBlock
+
+	record := func(name string, conditional bool) {
+
+		scoped := false
+
+		for _, b := range stack {
+			conditional = conditional || b.conditional
+			scoped = scoped || b.scoped
+		}
+
+		name, ok := templateVarName(name, scoped)
+
+		if !ok {
+			return
+		}
+
+		if opt, seen := optional[name]; !seen || opt {
+			optional[name] = conditional
+		}
+
+	}
+
+	for rest := text; ; {
+
+		open := strings.Index(rest, "{{")
+
+		if open < 0 {
+			return
+		}
+
+		rest = rest[open+2:]
+		closing := "}}"
+
+		if strings.HasPrefix(rest, "{") {
+			rest = rest[1:]
+			closing = "}}}"
+		}
+
+		end := strings.Index(rest, closing)
+
+		if end < 0 {
+			return
+		}
+
+		tag := strings.TrimSpace(strings.Trim(strings.TrimSpace(rest[:end]), "~"))
+		rest = rest[end+len(closing):]
+
+		if tag == "" {
+			continue
+		}
+
+		switch tag[0] {
+
+		case '!', '>':
+			continue
+
+		case '/':
+
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+			continue
+
+		case '#', '^':
+
+			fields := strings.Fields(tag[1:])
+
+			if len(fields) == 0 {
+				continue
+			}
+
+			helper := fields[0]
+			args := fields[1:]
+
+			if tag[0] == '^' {
+				helper, args = "unless", fields
+			}
+
+			block := templateBlock{
+				conditional: helper == "if" || helper == "unless",
+				scoped:      helper == "each" || helper == "with",
+			}
+
+			for _, arg := range args {
+				record(arg, block.conditional)
+			}
+
+			stack = append(stack, block)
+
+			continue
+
+		case '&':
+			tag = strings.TrimSpace(tag[1:])
+
+		}
+
+		fields := strings.Fields(tag)
+
+		if len(fields) == 0 || fields[0] == "else" {
+			continue
+		}
+
+		if len(fields) == 1 {
+			record(fields[0], false)
+			continue
+		}
+
+		for _, arg := range fields[1:] {
+
+			if _, value, ok := strings.Cut(arg, "="); ok {
+				arg = value
+			}
+
+			record(arg, false)
+
+		}
+
+	}
+
+}
+
+func templateVarName(name string, scoped bool) (string, bool) {
+
+	switch {
+
+	case strings.HasPrefix(name, "@root."):
+		name = strings.TrimPrefix(name, "@root.")
+
+	case strings.HasPrefix(name, "../"):
+		for strings.HasPrefix(name, "../") {
+			name = strings.TrimPrefix(name, "../")
+		}
+
+	case scoped:
+		return "", false
+
+	}
+
+	if name == "this" || strings.HasPrefix(name, "this.") || !templateVarRe.MatchString(name) {
+		return "", false
+	}
+
+	switch name {
+
+	case "true", "false", "null", "undefined":
+		return "", false
+
+	}
+
+	return name, true
+
+}
+
+func MissingTemplateData(vars []TemplateVariable, data map[string]any) []string {
+
+	var missing []string
+
+	for _, v := range vars {
+
+		if v.Optional {
+			continue
+		}
+
+		if !hasTemplateValue(data, v.Name) {
+			missing = append(missing, v.Name)
+		}
+
+	}
+
+	return missing
+
+}
+
+func hasTemplateValue(data map[string]any, path string) bool {
+
+	var cur any = data
+
+	for _, key := range strings.Split(path, ".") {
+
+		m, ok := cur.(map[string]any)
+
+		if !ok {
+			return false
+		}
+
+		if cur, ok = m[key]; !ok {
+			return false
+		}
+
+	}
+
+	return true
+
+}
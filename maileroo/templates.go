@@ -0,0 +1,161 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type Template struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Subject   string `json:"subject"`
+	HTML      string `json:"html"`
+	Plain     string `json:"plain"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+func (c *Client) GetTemplate(ctx context.Context, templateID int) (*Template, error) {
+
+	tpl, _, _, err := c.fetchTemplate(ctx, templateID, "")
+
+	return tpl, err
+
+}
+
+func (c *Client) fetchTemplate(ctx context.Context, templateID int, etag string) (*Template, string, bool, error) {
+
+	if templateID < 1 {
+		return nil, "", false, newFieldError("template_id", FieldErrorInvalid, "template_id must be a positive integer")
+	}
+
+	if etag != "" {
+		ctx = withRequestHeaders(ctx, http.Header{"If-None-Match": {etag}})
+	}
+
+	meta := &responseMeta{}
+	ctx = withResponseMeta(ctx, meta)
+
+	var out struct {
+		Success bool           `json:"success"`
+		Message string         `json:"message"`
+		Data    *Template      `json:"data"`
+		Errors  APIFieldErrors `json:"errors"`
+	}
+
+	if err := c.sendRequest(ctx, http.MethodGet, "templates/"+strconv.Itoa(templateID), nil, &out); err != nil {
+		return nil, "", false, err
+	}
+
+	if meta.status == http.StatusNotModified {
+		return nil, etag, true, nil
+	}
+
+	if out.Success && out.Data != nil {
+		return out.Data, meta.header.Get("ETag"), false, nil
+	}
+
+	return nil, "", false, newAPIError(out.Message, out.Errors)
+
+}
+
+type templateCacheEntry struct {
+	template  Template
+	etag      string
+	fetchedAt time.Time
+}
+
+type TemplateCache struct {
+	client  *Client
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[int]*templateCacheEntry
+}
+
+func NewTemplateCache(client *Client, ttl time.Duration) (*TemplateCache, error) {
+
+	if client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("ttl must be a positive duration")
+	}
+
+	return &TemplateCache{
+		client:  client,
+		ttl:     ttl,
+		entries: map[int]*templateCacheEntry{},
+	}, nil
+
+}
+
+func (tc *TemplateCache) Get(ctx context.Context, templateID int) (*Template, error) {
+
+	tc.mu.Lock()
+	entry, ok := tc.entries[templateID]
+
+	if ok && time.Since(entry.fetchedAt) < tc.ttl {
+		tpl := entry.template
+		tc.mu.Unlock()
+		return &tpl, nil
+	}
+
+	etag := ""
+
+	if ok {
+		etag = entry.etag
+	}
+
+	tc.mu.Unlock()
+
+	tpl, newETag, notModified, err := tc.client.fetchTemplate(ctx, templateID, etag)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+
+	if notModified && ok {
+		entry.fetchedAt = time.Now()
+		out := entry.template
+		return &out, nil
+	}
+
+	if tpl == nil {
+		return nil, fmt.Errorf("template %d was not returned by the API", templateID)
+	}
+
+	tc.entries[templateID] = &templateCacheEntry{
+		template:  *tpl,
+		etag:      newETag,
+		fetchedAt: time.Now(),
+	}
+
+	out := *tpl
+
+	return &out, nil
+
+}
+
+func (tc *TemplateCache) Invalidate(templateID int) {
+
+	tc.mu.Lock()
+	delete(tc.entries, templateID)
+	tc.mu.Unlock()
+
+}
+
+func (tc *TemplateCache) Purge() {
+
+	tc.mu.Lock()
+	tc.entries = map[int]*templateCacheEntry{}
+	tc.mu.Unlock()
+
+}
@@ -0,0 +1,59 @@
+package maileroo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const TestSubjectPrefix = "[TEST] "
+
+func (c *Client) SendTestEmail(ctx context.Context, templateID int, sampleData map[string]any, to EmailAddress) (string, error) {
+
+	if to.group != nil {
+		return "", newFieldError("to", FieldErrorInvalid, "test emails must be sent to a single address, not a recipient group")
+	}
+
+	if err := to.validate("to"); err != nil {
+		return "", err
+	}
+
+	tpl, err := c.GetTemplate(ctx, templateID)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to load template %d: %w", templateID, err)
+	}
+
+	subject := tpl.Subject
+
+	if strings.TrimSpace(subject) == "" {
+		subject = tpl.Name
+	}
+
+	if strings.TrimSpace(subject) == "" {
+		subject = fmt.Sprintf("Template %d", templateID)
+	}
+
+	subject, err = interpolatePlaceholders("subject", subject, sampleData, false)
+
+	if err != nil {
+		return "", err
+	}
+
+	subject = TestSubjectPrefix + strings.TrimPrefix(subject, TestSubjectPrefix)
+
+	if runes := []rune(subject); len(runes) > MaxSubjectLength {
+		subject = string(runes[:MaxSubjectLength])
+	}
+
+	tracking := false
+
+	return c.SendTemplatedEmail(ctx, TemplatedEmailData{
+		To:               []EmailAddress{to},
+		Subject:          subject,
+		TemplateID:       templateID,
+		TemplateData:     sampleData,
+		TrackingSettings: &TrackingSettings{Opens: &tracking, Clicks: &tracking},
+	})
+
+}
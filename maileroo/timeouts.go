@@ -0,0 +1,98 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const DefaultBulkTimeout = 2 * time.Minute
+
+type OperationClass string
+
+const (
+	OperationRead OperationClass = "read"
+	OperationSend OperationClass = "send"
+	OperationBulk OperationClass = "bulk"
+)
+
+type OperationTimeouts struct {
+	Read time.Duration
+	Send time.Duration
+	Bulk time.Duration
+}
+
+type requestTimeoutKey struct{}
+
+func WithOperationTimeouts(t OperationTimeouts) ClientOption {
+	return func(c *Client) error {
+		if t.Read < 0 || t.Send < 0 || t.Bulk < 0 {
+			return errors.New("operation timeouts must not be negative")
+		}
+		c.operationTimeouts = t
+		return nil
+	}
+}
+
+func WithRequestTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, d)
+}
+
+func (c *Client) operationTimeout(ctx context.Context, class OperationClass) time.Duration {
+
+	if d, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok && d > 0 {
+		return d
+	}
+
+	switch class {
+
+	case OperationSend:
+
+		if c.operationTimeouts.Send > 0 {
+			return c.operationTimeouts.Send
+		}
+
+	case OperationBulk:
+
+		if c.operationTimeouts.Bulk > 0 {
+			return c.operationTimeouts.Bulk
+		}
+
+		return max(c.Timeout, DefaultBulkTimeout)
+
+	default:
+
+		if c.operationTimeouts.Read > 0 {
+			return c.operationTimeouts.Read
+		}
+
+	}
+
+	return c.Timeout
+
+}
+
+func operationClassFor(method, endpoint string) OperationClass {
+
+	if method != http.MethodPost {
+		return OperationRead
+	}
+
+	path, _, _ := strings.Cut(endpoint, "?")
+	path = "/" + strings.Trim(path, "/")
+
+	switch {
+
+	case strings.HasSuffix(path, "/emails/bulk"):
+		return OperationBulk
+
+	case strings.HasSuffix(path, "/emails"), strings.HasSuffix(path, "/emails/template"):
+		return OperationSend
+
+	}
+
+	return OperationRead
+
+}
@@ -0,0 +1,194 @@
+package maileroo
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+type TrackingSettings struct {
+	Opens       *bool
+	Clicks      *bool
+	UTMSource   string
+	UTMMedium   string
+	UTMCampaign string
+}
+
+func (t *TrackingSettings) utmParams() url.Values {
+
+	v := url.Values{}
+
+	if t == nil {
+		return v
+	}
+
+	for k, val := range map[string]string{"utm_source": t.UTMSource, "utm_medium": t.UTMMedium, "utm_campaign": t.UTMCampaign} {
+
+		if val = strings.TrimSpace(val); val != "" {
+			v.Set(k, val)
+		}
+
+	}
+
+	return v
+
+}
+
+func WithUTMParams(source, medium, campaign string) ClientOption {
+	return func(c *Client) error {
+		t := &TrackingSettings{UTMSource: source, UTMMedium: medium, UTMCampaign: campaign}
+		params := t.utmParams()
+		if len(params) == 0 {
+			return errors.New("at least one of utm source, medium or campaign is required")
+		}
+		c.utmParams = params
+		return nil
+	}
+}
+
+func (c *Client) utmParamsFor(settings *TrackingSettings) url.Values {
+
+	params := url.Values{}
+
+	for k, v := range c.utmParams {
+		params[k] = v
+	}
+
+	for k, v := range settings.utmParams() {
+		params[k] = v
+	}
+
+	return params
+
+}
+
+func resolveTracking(tracking *bool, settings *TrackingSettings) (*bool, error) {
+
+	if settings == nil || (settings.Opens == nil && settings.Clicks == nil) {
+		return tracking, nil
+	}
+
+	if settings.Opens != nil && settings.Clicks != nil && *settings.Opens != *settings.Clicks {
+		return nil, newFieldError("tracking_settings", FieldErrorConflict, "open and click tracking can only be turned on or off together")
+	}
+
+	enabled := settings.Opens
+
+	if enabled == nil {
+		enabled = settings.Clicks
+	}
+
+	if tracking != nil && *tracking != *enabled {
+		return nil, newFieldError("tracking", FieldErrorConflict, "tracking %t conflicts with tracking settings", *tracking)
+	}
+
+	v := *enabled
+
+	return &v, nil
+
+}
+
+func tagLinksWithUTM(body *string, params url.Values) *string {
+
+	if body == nil || len(params) == 0 {
+		return body
+	}
+
+	z := html.NewTokenizer(strings.NewReader(*body))
+
+	var b strings.Builder
+
+	for {
+
+		tt := z.Next()
+
+		if tt == html.ErrorToken {
+			break
+		}
+
+		raw := z.Raw()
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			b.Write(raw)
+			continue
+		}
+
+		tok := z.Token()
+
+		if tok.DataAtom != atom.A || !tagHref(&tok, params) {
+			b.Write(raw)
+			continue
+		}
+
+		b.WriteString(tok.String())
+
+	}
+
+	out := b.String()
+
+	return &out
+
+}
+
+func tagHref(tok *html.Token, params url.Values) bool {
+
+	for i, attr := range tok.Attr {
+
+		if attr.Key != "href" {
+			continue
+		}
+
+		href := strings.TrimSpace(attr.Val)
+
+		if !strings.HasPrefix(href, "http://") && !strings.HasPrefix(href, "https://") {
+			return false
+		}
+
+		if strings.Contains(strings.ToLower(href), "unsubscribe") {
+			return false
+		}
+
+		u, err := url.Parse(href)
+
+		if err != nil || u.Host == "" {
+			return false
+		}
+
+		for k := range u.Query() {
+
+			if strings.HasPrefix(strings.ToLower(k), "utm_") {
+				return false
+			}
+
+		}
+
+		base, fragment, hasFragment := strings.Cut(href, "#")
+
+		sep := "?"
+
+		if strings.Contains(base, "?") {
+			sep = "&"
+		}
+
+		if strings.HasSuffix(base, "?") || strings.HasSuffix(base, "&") {
+			sep = ""
+		}
+
+		href = base + sep + params.Encode()
+
+		if hasFragment {
+			href += "#" + fragment
+		}
+
+		tok.Attr[i].Val = href
+
+		return true
+
+	}
+
+	return false
+
+}
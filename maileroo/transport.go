@@ -0,0 +1,102 @@
+package maileroo
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+const DefaultMaxResponseSize int64 = 10 << 20
+
+func WithConnectTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d <= 0 {
+			return errors.New("connect timeout must be a positive duration")
+		}
+		c.connectTimeout = d
+		return nil
+	}
+}
+
+func WithReadTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d <= 0 {
+			return errors.New("read timeout must be a positive duration")
+		}
+		c.readTimeout = d
+		return nil
+	}
+}
+
+func WithMaxResponseSize(n int64) ClientOption {
+	return func(c *Client) error {
+		if n <= 0 {
+			return errors.New("max response size must be a positive number of bytes")
+		}
+		c.maxResponseSize = n
+		return nil
+	}
+}
+
+type RoundTripperMiddleware func(next http.RoundTripper) http.RoundTripper
+
+func WithRoundTripperChain(middleware ...RoundTripperMiddleware) ClientOption {
+	return func(c *Client) error {
+		for _, mw := range middleware {
+			if mw == nil {
+				return errors.New("round tripper middleware must not be nil")
+			}
+		}
+		c.roundTrippers = append(c.roundTrippers, middleware...)
+		return nil
+	}
+}
+
+func (c *Client) buildTransport() http.RoundTripper {
+
+	rt := c.baseTransport()
+
+	if len(c.roundTrippers) == 0 {
+		return rt
+	}
+
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	for i := len(c.roundTrippers) - 1; i >= 0; i-- {
+		rt = c.roundTrippers[i](rt)
+	}
+
+	return rt
+
+}
+
+func (c *Client) baseTransport() http.RoundTripper {
+
+	if c.connectTimeout <= 0 && c.readTimeout <= 0 {
+		return nil
+	}
+
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.connectTimeout > 0 {
+
+		dialer := &net.Dialer{
+			Timeout:   c.connectTimeout,
+			KeepAlive: 30 * time.Second,
+		}
+
+		t.DialContext = dialer.DialContext
+		t.TLSHandshakeTimeout = c.connectTimeout
+
+	}
+
+	if c.readTimeout > 0 {
+		t.ResponseHeaderTimeout = c.readTimeout
+	}
+
+	return t
+
+}
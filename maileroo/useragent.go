@@ -0,0 +1,90 @@
+package maileroo
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+func WithUserAgent(ua string) ClientOption {
+	return func(c *Client) error {
+		if strings.TrimSpace(ua) == "" {
+			return errors.New("user agent must be a non-empty string")
+		}
+		if strings.ContainsAny(ua, "\r\n") {
+			return errors.New("user agent must not contain line breaks")
+		}
+		c.userAgent = strings.TrimSpace(ua)
+		return nil
+	}
+}
+
+func WithAppInfo(name, version string) ClientOption {
+	return func(c *Client) error {
+		if !isUserAgentToken(name) {
+			return errors.New("app name must be a non-empty string without whitespace or '/'")
+		}
+		if version != "" && !isUserAgentToken(version) {
+			return errors.New("app version must not contain whitespace or '/'")
+		}
+		info := name
+		if version != "" {
+			info += "/" + version
+		}
+		c.appInfo = append(c.appInfo, info)
+		return nil
+	}
+}
+
+func WithoutTelemetryHeaders() ClientOption {
+	return func(c *Client) error {
+		c.disableTelemetry = true
+		return nil
+	}
+}
+
+func (c *Client) userAgentHeader(ctx context.Context) string {
+
+	var parts []string
+
+	switch {
+
+	case c.userAgent != "":
+		parts = append(parts, c.userAgent)
+
+	case !c.disableTelemetry:
+		parts = append(parts, defaultUserAgent)
+
+	}
+
+	parts = append(parts, c.appInfo...)
+
+	if len(parts) > 0 {
+
+		if comment := userAgentComment(ContextTagsFrom(ctx)); comment != "" {
+			parts = append(parts, comment)
+		}
+
+	}
+
+	return strings.Join(parts, " ")
+
+}
+
+func isUserAgentToken(s string) bool {
+
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+
+		if r <= ' ' || r == '/' || r == 0x7f {
+			return false
+		}
+
+	}
+
+	return true
+
+}